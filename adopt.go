@@ -0,0 +1,39 @@
+package errors
+
+import (
+	"fmt"
+)
+
+// sourceTypeField is the field Adopt records the type of a foreign error in.
+const sourceTypeField = "source_type"
+
+// Adopt takes err, typically returned by a third-party package, into this
+// package. It records the name of the concrete type of err, such as
+// "*net.OpError", as the field "source_type", so the type is known in logs
+// even after the error has been wrapped and serialized. A stack trace is
+// recorded at the point Adopt is called, unless the chain of err has one
+// already. If err is nil, Adopt returns nil.
+func Adopt(err error) error {
+	if err == nil {
+		return nil
+	}
+	result := WithField(err, sourceTypeField, fmt.Sprintf("%T", err))
+	if hasStack(err) {
+		return result
+	}
+	return &withStack{
+		result,
+		callers(),
+	}
+}
+
+// FindSourceType returns the type name recorded by Adopt in the chain of
+// err.
+func FindSourceType(err error) (string, bool) {
+	value, ok := findField(err, sourceTypeField)
+	if !ok {
+		return "", false
+	}
+	sourceType, ok := value.(string)
+	return sourceType, ok
+}
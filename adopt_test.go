@@ -0,0 +1,61 @@
+package errors
+
+import (
+	"github.com/confetti-framework/syslog/log_level"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net"
+	"testing"
+)
+
+func Test_adopt_nil(t *testing.T) {
+	assert.Nil(t, Adopt(nil))
+}
+
+func Test_adopt_records_source_type(t *testing.T) {
+	opErr := &net.OpError{Op: "dial", Net: "tcp", Err: io.EOF}
+
+	err := Wrap(Adopt(opErr), "connect to database")
+
+	sourceType, ok := FindSourceType(err)
+	assert.True(t, ok)
+	assert.Equal(t, "*net.OpError", sourceType)
+	assert.Equal(t, "*net.OpError", FindFields(err)["source_type"])
+	var target *net.OpError
+	assert.True(t, As(err, &target))
+}
+
+func Test_adopt_records_stack(t *testing.T) {
+	err := Adopt(&net.OpError{Op: "dial", Net: "tcp", Err: io.EOF})
+
+	stack, ok := FindStack(err)
+	assert.True(t, ok)
+	assert.Contains(t, stack[0].Info().Function, "Test_adopt_records_stack")
+}
+
+func Test_adopt_keeps_existing_stack(t *testing.T) {
+	original := WithStack(io.EOF)
+
+	err := Adopt(original)
+
+	_, ok := err.(*withStack)
+	assert.False(t, ok)
+	sourceType, _ := FindSourceType(err)
+	assert.Equal(t, "*errors.withStack", sourceType)
+}
+
+func Test_adopt_keeps_stack_hidden_by_threshold(t *testing.T) {
+	SetStackLevelThreshold(log_level.ERROR)
+	defer ClearStackLevelThreshold()
+	original := WithLevel(WithStack(io.EOF), log_level.DEBUG)
+
+	err := Adopt(original)
+
+	_, ok := err.(*withStack)
+	assert.False(t, ok)
+}
+
+func Test_find_source_type_without_adopt(t *testing.T) {
+	_, ok := FindSourceType(Wrap(io.EOF, "read"))
+	assert.False(t, ok)
+}
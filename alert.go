@@ -0,0 +1,62 @@
+package errors
+
+import (
+	"fmt"
+	syslog "github.com/confetti-framework/syslog/log_level"
+)
+
+// WithAlert marks err as one that should alert an on-call engineer,
+// regardless of its level or status. If err is nil, WithAlert returns nil.
+func WithAlert(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &withAlert{err}
+}
+
+// ShouldAlert reports whether any layer in the chain of err is marked with
+// WithAlert.
+func ShouldAlert(err error) bool {
+	var alertHolder *withAlert
+	return As(err, &alertHolder)
+}
+
+type withAlert struct {
+	cause error
+}
+
+func (w *withAlert) Error() string {
+	return w.cause.Error()
+}
+
+func (w *withAlert) Format(st fmt.State, verb rune) {
+	formatCause(st, verb, w, w.cause)
+}
+
+func (w *withAlert) Wrap(message string, args ...interface{}) *withMessage {
+	return newMessage(w, message, args...)
+}
+
+func (w *withAlert) Unwrap() error {
+	return w.cause
+}
+
+func (w *withAlert) Cause() error {
+	return Unwrap(w)
+}
+
+func (w *withAlert) Level(level syslog.Level) *withLevel {
+	return withLevelAt(w, level, 1)
+}
+
+func (w *withAlert) Status(status int) *withStatus {
+	return WithStatus(w, status)
+}
+
+func (w *withAlert) Alert() *withAlert {
+	return &withAlert{w}
+}
+
+func (w *withAlert) With(key string, value interface{}) *withField {
+	return &withField{cause: w, key: key, value: value}
+}
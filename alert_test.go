@@ -0,0 +1,50 @@
+package errors
+
+import (
+	"github.com/confetti-framework/syslog/log_level"
+	"github.com/stretchr/testify/assert"
+	"io"
+	net "net/http"
+	"testing"
+)
+
+func Test_alert_with_nil(t *testing.T) {
+	var err error = WithAlert(nil)
+
+	assert.True(t, err == nil)
+}
+
+func Test_alert_without_alert(t *testing.T) {
+	assert.False(t, ShouldAlert(New("disk almost full").Level(log_level.EMERGENCY)))
+	assert.False(t, ShouldAlert(nil))
+}
+
+func Test_alert_through_wrapping(t *testing.T) {
+	err := Wrap(WithAlert(io.EOF), "replication stopped")
+
+	assert.True(t, ShouldAlert(err))
+	assert.True(t, ShouldAlert(WithStatus(err, net.StatusServiceUnavailable)))
+	assert.Equal(t, "replication stopped: EOF", err.Error())
+}
+
+func Test_alert_fluent(t *testing.T) {
+	err := New("replication stopped").Alert().Level(log_level.DEBUG).Wrap("database error")
+
+	assert.True(t, ShouldAlert(err))
+	level, ok := FindLevel(err)
+	assert.True(t, ok)
+	assert.Equal(t, log_level.DEBUG, level)
+}
+
+func Test_alert_fluent_on_wrappers(t *testing.T) {
+	errs := []error{
+		Wrap(io.EOF, "read failed").Alert(),
+		New("EOF").Wrap("read failed").Alert(),
+		WithLevel(io.EOF, log_level.INFO).Alert(),
+		WithStatus(io.EOF, net.StatusOK).Alert(),
+		New("read failed").Alert().Alert().Status(net.StatusOK),
+	}
+	for _, err := range errs {
+		assert.True(t, ShouldAlert(err))
+	}
+}
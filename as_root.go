@@ -0,0 +1,26 @@
+package errors
+
+// AsFromRoot finds the error of type T nearest to the root cause of err. It
+// walks the chain from the root cause outward and returns the first error
+// of type T, whereas As returns the outermost one.
+func AsFromRoot[T any](err error) (T, bool) {
+	links := Links(err)
+	for i := len(links) - 1; i >= 0; i-- {
+		if target, ok := links[i].(T); ok {
+			return target, true
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// ContainsType reports whether an error of type T is present anywhere in the
+// chain of err, without the need for a target variable as with As.
+func ContainsType[T any](err error) bool {
+	for _, link := range Links(err) {
+		if _, ok := link.(T); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func Test_as_from_root_innermost_status(t *testing.T) {
+	err := WithStatus(Wrap(WithStatus(io.EOF, 404), "load user"), 500)
+
+	status, ok := AsFromRoot[*withStatus](err)
+
+	assert.True(t, ok)
+	assert.Equal(t, 404, status.status)
+}
+
+func Test_as_from_root_interface(t *testing.T) {
+	err := WithStatus(Wrap(WithStatus(io.EOF, 404), "load user"), 500)
+
+	coder, ok := AsFromRoot[StatusCoder](err)
+
+	assert.True(t, ok)
+	assert.Equal(t, 404, coder.StatusCode())
+}
+
+func Test_as_from_root_not_found(t *testing.T) {
+	status, ok := AsFromRoot[*withStatus](Wrap(io.EOF, "read"))
+
+	assert.False(t, ok)
+	assert.Nil(t, status)
+}
+
+func Test_as_from_root_nil(t *testing.T) {
+	_, ok := AsFromRoot[error](nil)
+
+	assert.False(t, ok)
+}
+
+func Test_contains_type_present(t *testing.T) {
+	err := Wrap(WithStatus(WithCode(io.EOF, "E1"), 404), "load user")
+
+	assert.True(t, ContainsType[*withCode](err))
+	assert.True(t, ContainsType[*withStatus](err))
+	assert.True(t, ContainsType[StatusCoder](err))
+}
+
+func Test_contains_type_absent(t *testing.T) {
+	err := Wrap(WithStatus(io.EOF, 404), "load user")
+
+	assert.False(t, ContainsType[*withCode](err))
+	assert.False(t, ContainsType[*withStatus](nil))
+}
+
+func Test_contains_type_through_join(t *testing.T) {
+	err := Join(io.EOF, Wrap(WithCode(io.EOF, "E1"), "second"))
+
+	assert.True(t, ContainsType[*withCode](err))
+}
@@ -0,0 +1,97 @@
+package errors
+
+import (
+	"fmt"
+	syslog "github.com/confetti-framework/syslog/log_level"
+	"io"
+)
+
+// Because returns f with cause as its underlying error, composing the
+// message as "<message>: <cause>". It reads like Wrap from the outer side:
+//
+//	return errors.New("cannot save order").Because(err)
+//
+// The stack trace of f is kept. If cause is nil, the result unwraps to f and
+// otherwise behaves like f.
+func (f *fundamental) Because(cause error) *withBecause {
+	return &withBecause{
+		outer: f,
+		cause: cause,
+	}
+}
+
+type withBecause struct {
+	outer *fundamental
+	cause error
+}
+
+func (w *withBecause) Error() string {
+	if w.cause == nil || w.cause.Error() == "" {
+		return w.outer.msg
+	}
+	return w.outer.msg + ": " + w.cause.Error()
+}
+
+func (w *withBecause) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			if w.cause == nil {
+				w.outer.Format(s, verb)
+				return
+			}
+			if formatTrace(s, w) {
+				return
+			}
+			fmt.Fprintf(s, "%+v\n", w.cause)
+			formatWithStack(s, w.outer.stack, w.outer.msg)
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, w.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", w.Error())
+	case 'j':
+		formatJSON(s, w)
+	}
+}
+
+func (w *withBecause) StackTrace() StackTrace {
+	return w.outer.StackTrace()
+}
+
+func (w *withBecause) Unwrap() error {
+	if w.cause == nil {
+		return w.outer
+	}
+	return w.cause
+}
+
+func (w *withBecause) Cause() error {
+	return Unwrap(w)
+}
+
+func (w *withBecause) Is(target error) bool {
+	return target == error(w.outer)
+}
+
+func (w *withBecause) Wrap(message string, args ...interface{}) *withMessage {
+	return newMessage(w, message, args...)
+}
+
+func (w *withBecause) Level(level syslog.Level) *withLevel {
+	return withLevelAt(w, level, 1)
+}
+
+func (w *withBecause) Status(status int) *withStatus {
+	return WithStatus(w, status)
+}
+
+func (w *withBecause) Alert() *withAlert {
+	return &withAlert{w}
+}
+
+func (w *withBecause) With(key string, value interface{}) *withField {
+	return &withField{cause: w, key: key, value: value}
+}
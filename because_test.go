@@ -0,0 +1,55 @@
+package errors
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	net "net/http"
+	"testing"
+)
+
+func Test_because_nil_cause(t *testing.T) {
+	err := New("cannot save order")
+
+	because := err.Because(nil)
+
+	assert.Equal(t, err, Unwrap(because))
+	assert.Equal(t, "cannot save order", because.Error())
+	assert.True(t, Is(because, err))
+	assert.Len(t, FindTrace(because), 1)
+}
+
+func Test_because_composes_message(t *testing.T) {
+	err := New("cannot save order").Because(io.EOF)
+
+	assert.Equal(t, "cannot save order: EOF", err.Error())
+	assert.Equal(t, "cannot save order", New("cannot save order").Because(New("")).Error())
+}
+
+func Test_because_unwraps_to_cause(t *testing.T) {
+	outer := New("cannot save order")
+	err := outer.Because(io.EOF)
+
+	assert.Equal(t, io.EOF, err.Unwrap())
+	assert.Equal(t, io.EOF, Unwrap(err))
+	assert.True(t, Is(err, io.EOF))
+	assert.True(t, Is(err, outer))
+}
+
+func Test_because_keeps_stack(t *testing.T) {
+	outer := New("cannot save order")
+
+	stack, ok := FindStack(outer.Because(io.EOF))
+	assert.True(t, ok)
+	assert.Equal(t, outer.StackTrace(), stack)
+	assert.Contains(t, fmt.Sprintf("%+v", outer.Because(io.EOF)), "EOF\ncannot save order\n")
+}
+
+func Test_because_with_status(t *testing.T) {
+	err := WithStatus(New("cannot save order").Because(io.EOF), net.StatusConflict).Wrap("checkout")
+
+	status, ok := FindStatus(err)
+	assert.True(t, ok)
+	assert.Equal(t, net.StatusConflict, status)
+	assert.Equal(t, "checkout: cannot save order: EOF", err.Error())
+}
@@ -108,3 +108,77 @@ func BenchmarkStackFormatting(b *testing.B) {
 	}
 	GlobalE = stackStr
 }
+
+func BenchmarkStackEnabled(b *testing.B) {
+	for _, enabled := range []bool{true, false} {
+		name := fmt.Sprintf("enabled-%t", enabled)
+		b.Run(name, func(b *testing.B) {
+			SetStackEnabled(enabled)
+			defer SetStackEnabled(true)
+
+			var err error
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				err = yesErrors(0, 10)
+			}
+			b.StopTimer()
+			GlobalE = err
+		})
+	}
+}
+
+func BenchmarkFrameCache(b *testing.B) {
+	for _, enabled := range []bool{false, true} {
+		name := fmt.Sprintf("cache-%t", enabled)
+		b.Run(name, func(b *testing.B) {
+			SetFrameCache(enabled)
+			defer SetFrameCache(false)
+
+			err := yesErrors(0, 30)
+			// the first format symbolizes the frames
+			stackStr := fmt.Sprintf("%+v", err)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				stackStr = fmt.Sprintf("%+v", err)
+			}
+			b.StopTimer()
+			GlobalE = stackStr
+		})
+	}
+}
+
+func BenchmarkNewPooled(b *testing.B) {
+	b.Run("new", func(b *testing.B) {
+		SetStackEnabled(false)
+		defer SetStackEnabled(true)
+		var msg string
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			msg = New("cache miss").Error()
+		}
+		GlobalE = msg
+	})
+	b.Run("pooled", func(b *testing.B) {
+		var msg string
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			err := NewPooled("cache miss")
+			msg = err.Error()
+			Release(err)
+		}
+		GlobalE = msg
+	})
+}
+
+func BenchmarkHook(b *testing.B) {
+	SetErrorHook(func(err error) error { return err })
+	defer SetErrorHook(nil)
+
+	var msg string
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		msg = New("cache miss").Error()
+	}
+	GlobalE = msg
+}
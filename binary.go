@@ -0,0 +1,90 @@
+package errors
+
+import (
+	"bytes"
+	"encoding/gob"
+	syslog "github.com/confetti-framework/syslog/log_level"
+)
+
+// binaryError is the binary representation of an error. The stack is kept
+// as symbolized frames, since program counters are meaningless in another
+// process.
+type binaryError struct {
+	Message      string
+	Status       int
+	HasLevel     bool
+	Level        int
+	Code         string
+	HasErrorCode bool
+	ErrorCode    int
+	Stack        []FrameInfo
+}
+
+// EncodeBinary returns a gob encoding of err for passing it between
+// services: its message together with the status, level and codes found in
+// its chain, and the frames of its outermost stack trace. Unlike
+// MarshalJSON, the encoding keeps the stack trace and is meant to be turned
+// back into an error with DecodeBinary. EncodeBinary returns nil if err is
+// nil.
+func EncodeBinary(err error) ([]byte, error) {
+	if err == nil {
+		return nil, nil
+	}
+	result := binaryError{Message: err.Error()}
+	if status, ok := FindStatus(err); ok {
+		result.Status = status
+	}
+	if level, ok := FindLevel(err); ok {
+		result.HasLevel = true
+		result.Level = int(level)
+	}
+	if code, ok := FindCode(err); ok {
+		result.Code = code
+	}
+	if code, ok := FindNumericCode(err); ok {
+		result.HasErrorCode = true
+		result.ErrorCode = code
+	}
+	if frames, ok := FindFrames(err); ok {
+		result.Stack = frames
+	}
+
+	var buf bytes.Buffer
+	if encodeErr := gob.NewEncoder(&buf).Encode(result); encodeErr != nil {
+		return nil, encodeErr
+	}
+	return buf.Bytes(), nil
+}
+
+// DecodeBinary returns the error encoded by EncodeBinary. The result has
+// the original message, status, level and codes, and the original stack
+// trace as an explicit stack (see WithExplicitStack); it does not record a
+// stack of its own. DecodeBinary returns a nil error if data is empty, and
+// an error as second result if data is not a valid encoding.
+func DecodeBinary(data []byte) (error, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	var decoded binaryError
+	if decodeErr := gob.NewDecoder(bytes.NewReader(data)).Decode(&decoded); decodeErr != nil {
+		return nil, Wrap(decodeErr, "decode binary error")
+	}
+
+	var result error = &fundamental{msg: decoded.Message, stack: emptyStack}
+	if len(decoded.Stack) > 0 {
+		result = WithExplicitStack(result, decoded.Stack)
+	}
+	if decoded.HasErrorCode {
+		result = WithNumericCode(result, decoded.ErrorCode)
+	}
+	if decoded.Code != "" {
+		result = WithCode(result, decoded.Code)
+	}
+	if decoded.HasLevel {
+		result = &withLevel{result, syslog.Level(decoded.Level)}
+	}
+	if decoded.Status != 0 {
+		result = WithStatus(result, decoded.Status)
+	}
+	return result, nil
+}
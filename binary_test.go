@@ -0,0 +1,110 @@
+package errors
+
+import (
+	"github.com/confetti-framework/syslog/log_level"
+	"github.com/stretchr/testify/assert"
+	net "net/http"
+	"testing"
+)
+
+func Test_encode_binary_nil(t *testing.T) {
+	data, err := EncodeBinary(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, data)
+
+	decoded, err := DecodeBinary(data)
+	assert.NoError(t, err)
+	assert.Nil(t, decoded)
+}
+
+func Test_binary_round_trip(t *testing.T) {
+	var err error = Wrap(New("user not found"), "load user")
+	err = WithLevel(WithStatus(WithCode(err, "E1"), net.StatusNotFound), log_level.WARNING)
+
+	data, encodeErr := EncodeBinary(err)
+	assert.NoError(t, encodeErr)
+	decoded, decodeErr := DecodeBinary(data)
+	assert.NoError(t, decodeErr)
+
+	assert.EqualError(t, decoded, "load user: user not found")
+	status, ok := FindStatus(decoded)
+	assert.True(t, ok)
+	assert.Equal(t, net.StatusNotFound, status)
+	level, ok := FindLevel(decoded)
+	assert.True(t, ok)
+	assert.Equal(t, log_level.WARNING, level)
+	code, ok := FindCode(decoded)
+	assert.True(t, ok)
+	assert.Equal(t, "E1", code)
+
+	original, _ := FindFrames(err)
+	frames, ok := FindFrames(decoded)
+	assert.True(t, ok)
+	assert.Equal(t, original, frames)
+	assert.Equal(t, "github.com/confetti-framework/errors.Test_binary_round_trip", frames[0].Function)
+}
+
+func Test_binary_round_trip_without_metadata(t *testing.T) {
+	SetStackEnabled(false)
+	defer SetStackEnabled(true)
+
+	data, encodeErr := EncodeBinary(New("plain"))
+	assert.NoError(t, encodeErr)
+	decoded, decodeErr := DecodeBinary(data)
+	assert.NoError(t, decodeErr)
+
+	assert.EqualError(t, decoded, "plain")
+	_, ok := FindStatus(decoded)
+	assert.False(t, ok)
+	_, ok = FindLevel(decoded)
+	assert.False(t, ok)
+	_, ok = FindCode(decoded)
+	assert.False(t, ok)
+	_, ok = FindNumericCode(decoded)
+	assert.False(t, ok)
+	_, ok = FindFrames(decoded)
+	assert.False(t, ok)
+}
+
+func Test_binary_round_trip_numeric_code(t *testing.T) {
+	data, encodeErr := EncodeBinary(WithNumericCode(Wrap(New("user not found"), "load user"), 0))
+	assert.NoError(t, encodeErr)
+	decoded, decodeErr := DecodeBinary(data)
+	assert.NoError(t, decodeErr)
+
+	code, ok := FindNumericCode(decoded)
+	assert.True(t, ok)
+	assert.Equal(t, 0, code)
+
+	data, _ = EncodeBinary(WithNumericCode(New("user not found"), 1001))
+	decoded, _ = DecodeBinary(data)
+	code, ok = FindNumericCode(decoded)
+	assert.True(t, ok)
+	assert.Equal(t, 1001, code)
+}
+
+func Test_decode_binary_invalid(t *testing.T) {
+	decoded, err := DecodeBinary([]byte("not gob"))
+	assert.Error(t, err)
+	assert.Nil(t, decoded)
+}
+
+func Test_binary_decode_with_stack_level_threshold(t *testing.T) {
+	SetStackLevelThreshold(log_level.ERROR)
+	defer ClearStackLevelThreshold()
+	err := WithLevel(New("disk full"), log_level.CRITICAL)
+
+	data, encodeErr := EncodeBinary(err)
+	assert.NoError(t, encodeErr)
+	decoded, decodeErr := DecodeBinary(data)
+	assert.NoError(t, decodeErr)
+
+	original, _ := FindFrames(err)
+	frames, ok := FindFrames(decoded)
+	assert.True(t, ok)
+	assert.Equal(t, original, frames)
+	for _, link := range Links(decoded) {
+		_, isStack := link.(*withStack)
+		assert.False(t, isStack)
+	}
+}
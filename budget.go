@@ -0,0 +1,66 @@
+package errors
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	budgetMu     sync.Mutex
+	budget       int
+	budgetWindow time.Duration
+	budgetSites  map[uintptr]*siteBudget
+	budgetActive int32
+)
+
+type siteBudget struct {
+	start time.Time
+	count int
+}
+
+// SetStackCaptureBudget limits the number of stack traces captured at a
+// single call site to n per window, to bound the overhead of an error that
+// is created at a high rate. Errors beyond the budget have no stack trace
+// until the window of their call site has passed. A budget of 0 or less
+// removes the limit, which is the default. Setting a budget resets the
+// windows of all call sites.
+func SetStackCaptureBudget(n int, window time.Duration) {
+	budgetMu.Lock()
+	defer budgetMu.Unlock()
+	budget = n
+	budgetWindow = window
+	budgetSites = map[uintptr]*siteBudget{}
+	var active int32
+	if n > 0 {
+		active = 1
+	}
+	atomic.StoreInt32(&budgetActive, active)
+}
+
+// stackBudgetActive reports whether a budget is set, so the call site only
+// has to be determined when it is.
+func stackBudgetActive() bool {
+	return atomic.LoadInt32(&budgetActive) == 1
+}
+
+// withinStackBudget records a stack capture at the call site pc and reports
+// whether the budget of that site permits it.
+func withinStackBudget(pc uintptr) bool {
+	if !stackBudgetActive() || pc == 0 {
+		return true
+	}
+	now := currentTime()
+	budgetMu.Lock()
+	defer budgetMu.Unlock()
+	if budget <= 0 {
+		return true
+	}
+	site, ok := budgetSites[pc]
+	if !ok || now.Sub(site.start) >= budgetWindow {
+		budgetSites[pc] = &siteBudget{start: now, count: 1}
+		return true
+	}
+	site.count++
+	return site.count <= budget
+}
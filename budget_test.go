@@ -0,0 +1,56 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+	"time"
+)
+
+func budgetedStacks(n int) []bool {
+	var stacks []bool
+	for i := 0; i < n; i++ {
+		_, ok := FindStack(New("hot"))
+		stacks = append(stacks, ok)
+	}
+	return stacks
+}
+
+func Test_stack_capture_budget_unlimited(t *testing.T) {
+	assert.Equal(t, []bool{true, true, true}, budgetedStacks(3))
+}
+
+func Test_stack_capture_budget_per_site(t *testing.T) {
+	SetStackCaptureBudget(2, time.Minute)
+	defer SetStackCaptureBudget(0, 0)
+
+	assert.Equal(t, []bool{true, true, false, false}, budgetedStacks(4))
+
+	_, ok := FindStack(New("other site"))
+	assert.True(t, ok)
+}
+
+func Test_stack_capture_budget_window_resets(t *testing.T) {
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	SetClock(func() time.Time { return now })
+	defer SetClock(nil)
+	SetStackCaptureBudget(1, time.Minute)
+	defer SetStackCaptureBudget(0, 0)
+
+	assert.Equal(t, []bool{true, false}, budgetedStacks(2))
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, []bool{true, false}, budgetedStacks(2))
+}
+
+func Test_stack_capture_budget_wrap(t *testing.T) {
+	SetStackCaptureBudget(1, time.Minute)
+	defer SetStackCaptureBudget(0, 0)
+
+	var stacks []bool
+	for i := 0; i < 2; i++ {
+		_, ok := FindStack(Wrap(io.EOF, "wrap"))
+		stacks = append(stacks, ok)
+	}
+	assert.Equal(t, []bool{true, false}, stacks)
+}
@@ -0,0 +1,17 @@
+package errors
+
+// WrapEach returns a new slice in which every non-nil error of errs is
+// annotated with status, as with WithStatus. Nil errors stay nil, so the
+// result keeps lining up with the items of a bulk operation.
+func WrapEach(errs []error, status int) []error {
+	if errs == nil {
+		return nil
+	}
+	wrapped := make([]error, len(errs))
+	for i, err := range errs {
+		if err != nil {
+			wrapped[i] = WithStatus(err, status)
+		}
+	}
+	return wrapped
+}
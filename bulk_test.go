@@ -0,0 +1,30 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	net "net/http"
+	"testing"
+)
+
+func Test_wrap_each_nil(t *testing.T) {
+	assert.Nil(t, WrapEach(nil, net.StatusConflict))
+	assert.Empty(t, WrapEach([]error{}, net.StatusConflict))
+}
+
+func Test_wrap_each(t *testing.T) {
+	errs := []error{io.EOF, nil, NotFound("user not found"), nil}
+
+	wrapped := WrapEach(errs, net.StatusConflict)
+
+	assert.Len(t, wrapped, 4)
+	assert.Nil(t, wrapped[1])
+	assert.Nil(t, wrapped[3])
+	for _, i := range []int{0, 2} {
+		status, ok := FindStatus(wrapped[i])
+		assert.True(t, ok)
+		assert.Equal(t, net.StatusConflict, status)
+		assert.True(t, Is(wrapped[i], errs[i]))
+	}
+	assert.Equal(t, io.EOF, errs[0])
+}
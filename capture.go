@@ -0,0 +1,89 @@
+package errors
+
+import (
+	"sync/atomic"
+)
+
+// StackCapturer captures the program counters of the calling goroutine.
+// The default implementation uses runtime.Callers; a custom implementation
+// can be installed with SetStackCapturer, e.g. for platforms where
+// runtime.Callers is unsupported or to get deterministic stacks in tests.
+type StackCapturer interface {
+	// Capture returns the program counters of the stack, skipping the given
+	// number of frames. With skip 0 the first frame is the caller of Capture.
+	Capture(skip int) []uintptr
+}
+
+var (
+	// stackCapturer holds a capturerHolder with the custom capturer, if any.
+	stackCapturer atomic.Value
+	// customCapturer is 1 while a custom capturer is installed, so the
+	// default capturer is used without loading stackCapturer.
+	customCapturer int32
+	stackDisabled  int32
+)
+
+// capturerHolder gives every capturer stored in stackCapturer the same
+// concrete type, as atomic.Value requires.
+type capturerHolder struct {
+	StackCapturer
+}
+
+// stackDepth is the maximum number of frames that are captured.
+const stackDepth = 32
+
+// emptyStack is shared by all errors created while stacks are disabled.
+var emptyStack = &stack{}
+
+// SetStackCapturer replaces the StackCapturer used by New, Wrap and
+// WithStack. A nil capturer restores the default.
+func SetStackCapturer(capturer StackCapturer) {
+	if capturer == nil {
+		atomic.StoreInt32(&customCapturer, 0)
+		return
+	}
+	stackCapturer.Store(capturerHolder{capturer})
+	atomic.StoreInt32(&customCapturer, 1)
+}
+
+func currentStackCapturer() StackCapturer {
+	if atomic.LoadInt32(&customCapturer) == 0 {
+		return defaultStackCapturer{}
+	}
+	return stackCapturer.Load().(capturerHolder).StackCapturer
+}
+
+// SetStackEnabled turns stack capture on or off for New, Wrap, WithStack and
+// the other constructors. Errors created while it is off have an empty
+// StackTrace and FindStack reports no stack trace for them. It is on by
+// default and can be toggled at any time.
+func SetStackEnabled(enabled bool) {
+	var disabled int32
+	if !enabled {
+		disabled = 1
+	}
+	atomic.StoreInt32(&stackDisabled, disabled)
+}
+
+func stackEnabled() bool {
+	return atomic.LoadInt32(&stackDisabled) == 0
+}
+
+var skipEmptyStack int32
+
+// SetSkipEmptyStack controls whether New("") records a stack trace. Such
+// errors are often placeholders that only get metadata, which makes the
+// stack trace wasted work. When enabled, New with an empty message and no
+// arguments records no stack trace and FindStack reports none for it,
+// until a wrapper adds one. It is off by default.
+func SetSkipEmptyStack(enabled bool) {
+	var skip int32
+	if enabled {
+		skip = 1
+	}
+	atomic.StoreInt32(&skipEmptyStack, skip)
+}
+
+func skipEmptyStackEnabled() bool {
+	return atomic.LoadInt32(&skipEmptyStack) == 1
+}
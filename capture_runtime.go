@@ -0,0 +1,50 @@
+//go:build !tinygo
+// +build !tinygo
+
+package errors
+
+import (
+	"runtime"
+)
+
+// defaultStackCapturer captures stacks with runtime.Callers.
+type defaultStackCapturer struct{}
+
+func (defaultStackCapturer) Capture(skip int) []uintptr {
+	var pcs [stackDepth]uintptr
+	// skip runtime.Callers and Capture itself
+	n := runtime.Callers(skip+2, pcs[:])
+	return pcs[0:n]
+}
+
+// defaultCapture is the capture of defaultStackCapturer for callers and
+// callersAt. It is small enough to be inlined, which saves the frame of
+// Capture on every captured stack.
+func defaultCapture(skip int, pcs []uintptr) int {
+	// skip runtime.Callers and defaultCapture itself
+	return runtime.Callers(skip+2, pcs)
+}
+
+// callerPC returns the program counter of the frame skip frames above the
+// caller of callerPC, with the same meaning of skip as Capture.
+func callerPC(skip int) uintptr {
+	var pcs [1]uintptr
+	// skip runtime.Callers and callerPC itself
+	if runtime.Callers(skip+2, pcs[:]) == 0 {
+		return 0
+	}
+	return pcs[0]
+}
+
+// maxCallerDepth is the maximum depth callerDepth reports.
+const maxCallerDepth = 1024
+
+// callerDepth returns the number of frames on the stack above the frame
+// skip frames above the caller of callerDepth, with the same meaning of
+// skip as Capture, up to maxCallerDepth.
+func callerDepth(skip int) (int, bool) {
+	var pcs [maxCallerDepth]uintptr
+	// skip runtime.Callers and callerDepth itself
+	n := runtime.Callers(skip+2, pcs[:])
+	return n, n > 0
+}
@@ -0,0 +1,104 @@
+package errors
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+type fakeStackCapturer struct {
+	pcs []uintptr
+}
+
+func (c fakeStackCapturer) Capture(skip int) []uintptr {
+	return c.pcs
+}
+
+func Test_stack_capturer_fake(t *testing.T) {
+	SetStackCapturer(fakeStackCapturer{[]uintptr{1, 2, 3}})
+	defer SetStackCapturer(nil)
+
+	want := StackTrace{1, 2, 3}
+	assert.Equal(t, want, New("not found").StackTrace())
+	assert.Equal(t, want, Wrap(io.EOF, "read failed").StackTrace())
+
+	stack, ok := FindStack(WithStack(io.EOF))
+	assert.True(t, ok)
+	assert.Equal(t, want, stack)
+}
+
+func Test_stack_capturer_default(t *testing.T) {
+	SetStackCapturer(nil)
+
+	stack := New("not found").StackTrace()
+	assert.NotEmpty(t, stack)
+	assert.Equal(t, "Test_stack_capturer_default", funcname(stack[0].name()))
+}
+
+func Test_stack_disabled(t *testing.T) {
+	SetStackEnabled(false)
+	defer SetStackEnabled(true)
+
+	errs := []error{
+		New("not found"),
+		Wrap(io.EOF, "read failed"),
+		WithStack(io.EOF),
+		NotFound("user not found"),
+	}
+	for _, err := range errs {
+		_, ok := FindStack(err)
+		assert.False(t, ok)
+	}
+	assert.Empty(t, New("not found").StackTrace())
+	assert.Equal(t, "not found", fmt.Sprintf("%+v", New("not found")))
+}
+
+func Test_stack_disabled_keeps_earlier_stack(t *testing.T) {
+	inner := New("not found")
+
+	SetStackEnabled(false)
+	defer SetStackEnabled(true)
+
+	stack, ok := FindStack(Wrap(inner, "database error"))
+	assert.True(t, ok)
+	assert.Equal(t, inner.StackTrace(), stack)
+}
+
+func Test_stack_enabled_again(t *testing.T) {
+	SetStackEnabled(false)
+	SetStackEnabled(true)
+
+	_, ok := FindStack(New("not found"))
+	assert.True(t, ok)
+}
+
+func Test_skip_empty_stack_disabled(t *testing.T) {
+	_, ok := FindStack(New(""))
+	assert.True(t, ok)
+}
+
+func Test_skip_empty_stack(t *testing.T) {
+	SetSkipEmptyStack(true)
+	defer SetSkipEmptyStack(false)
+
+	_, ok := FindStack(New(""))
+	assert.False(t, ok)
+	_, ok = FindStack(New("").Status(404))
+	assert.False(t, ok)
+
+	_, ok = FindStack(New("").Wrap("load user"))
+	assert.False(t, ok)
+	_, ok = FindStack(Wrap(New(""), "load user"))
+	assert.True(t, ok)
+}
+
+func Test_skip_empty_stack_keeps_other_messages(t *testing.T) {
+	SetSkipEmptyStack(true)
+	defer SetSkipEmptyStack(false)
+
+	_, ok := FindStack(New("not found"))
+	assert.True(t, ok)
+	_, ok = FindStack(New("%s", ""))
+	assert.True(t, ok)
+}
@@ -0,0 +1,31 @@
+//go:build tinygo
+// +build tinygo
+
+package errors
+
+// defaultStackCapturer captures no stack, since runtime.Callers is not
+// supported under TinyGo. Install a StackCapturer to capture stacks anyway.
+type defaultStackCapturer struct{}
+
+func (defaultStackCapturer) Capture(skip int) []uintptr {
+	return nil
+}
+
+// defaultCapture captures no stack, like defaultStackCapturer.
+func defaultCapture(skip int, pcs []uintptr) int {
+	return 0
+}
+
+// callerPC returns 0, which exempts every call site from the stack capture
+// budget.
+func callerPC(skip int) uintptr {
+	return 0
+}
+
+// maxCallerDepth is the maximum depth callerDepth reports.
+const maxCallerDepth = 1024
+
+// callerDepth reports false, since the depth of the stack is unknown.
+func callerDepth(skip int) (int, bool) {
+	return 0, false
+}
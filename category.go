@@ -0,0 +1,77 @@
+package errors
+
+import (
+	"fmt"
+	syslog "github.com/confetti-framework/syslog/log_level"
+	"strings"
+)
+
+// WithCategory annotates err with a dotted category such as "db.timeout".
+// Categories are hierarchical: InCategory matches "db.timeout" against both
+// "db" and "db.timeout". If err is nil, WithCategory returns nil.
+func WithCategory(err error, category string) error {
+	if err == nil {
+		return nil
+	}
+	return &withCategory{
+		err,
+		category,
+	}
+}
+
+// InCategory reports whether any category in the chain of err is prefix or
+// a subcategory of it. The prefix must match whole segments, so "db" matches
+// "db.timeout" but not "dbx".
+func InCategory(err error, prefix string) bool {
+	for _, link := range Links(err) {
+		if categoryHolder, ok := link.(*withCategory); ok && categoryHolder.in(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+type withCategory struct {
+	cause    error
+	category string
+}
+
+func (w *withCategory) in(prefix string) bool {
+	return w.category == prefix || strings.HasPrefix(w.category, prefix+".")
+}
+
+func (w *withCategory) Error() string {
+	return w.cause.Error()
+}
+
+func (w *withCategory) Format(st fmt.State, verb rune) {
+	formatCause(st, verb, w, w.cause)
+}
+
+func (w *withCategory) Unwrap() error {
+	return w.cause
+}
+
+func (w *withCategory) Cause() error {
+	return Unwrap(w)
+}
+
+func (w *withCategory) Wrap(message string, args ...interface{}) *withMessage {
+	return newMessage(w, message, args...)
+}
+
+func (w *withCategory) Level(level syslog.Level) *withLevel {
+	return withLevelAt(w, level, 1)
+}
+
+func (w *withCategory) Status(status int) *withStatus {
+	return WithStatus(w, status)
+}
+
+func (w *withCategory) Alert() *withAlert {
+	return &withAlert{w}
+}
+
+func (w *withCategory) With(key string, value interface{}) *withField {
+	return &withField{cause: w, key: key, value: value}
+}
@@ -0,0 +1,36 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func Test_with_category_nil(t *testing.T) {
+	assert.Nil(t, WithCategory(nil, "db"))
+}
+
+func Test_in_category(t *testing.T) {
+	err := Wrap(WithCategory(io.EOF, "db.timeout"), "load user")
+
+	assert.True(t, InCategory(err, "db"))
+	assert.True(t, InCategory(err, "db.timeout"))
+	assert.False(t, InCategory(err, "net"))
+	assert.False(t, InCategory(err, "db.timeout.read"))
+	assert.False(t, InCategory(err, "d"))
+	assert.Equal(t, "load user: EOF", err.Error())
+}
+
+func Test_in_category_whole_segments(t *testing.T) {
+	err := WithCategory(io.EOF, "dbx.timeout")
+
+	assert.False(t, InCategory(err, "db"))
+}
+
+func Test_in_category_any_layer(t *testing.T) {
+	err := WithCategory(WithCategory(io.EOF, "net.dial"), "db.timeout")
+
+	assert.True(t, InCategory(err, "net"))
+	assert.True(t, InCategory(err, "db"))
+	assert.False(t, InCategory(nil, "db"))
+}
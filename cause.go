@@ -0,0 +1,89 @@
+package errors
+
+import (
+	"fmt"
+	syslog "github.com/confetti-framework/syslog/log_level"
+	"io"
+)
+
+// WrapCause returns an error that reports the message of outer, but unwraps
+// to cause. It models a high-level error that was caused by a low-level one
+// without concatenating their messages. Is and As also match against outer,
+// so metadata attached to outer remains discoverable.
+// If outer is nil, WrapCause returns nil. If cause is nil, outer is returned.
+func WrapCause(outer error, cause error) error {
+	if outer == nil {
+		return nil
+	}
+	if cause == nil {
+		return outer
+	}
+	return &withCause{
+		outer: outer,
+		cause: cause,
+	}
+}
+
+type withCause struct {
+	outer error
+	cause error
+}
+
+func (w *withCause) Error() string {
+	return w.outer.Error()
+}
+
+func (w *withCause) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			fmt.Fprintf(s, "%+v\n", w.outer)
+			io.WriteString(s, "caused by: ")
+			fmt.Fprintf(s, "%+v", w.cause)
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, w.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", w.Error())
+	case 'j':
+		formatJSON(s, w)
+	}
+}
+
+func (w *withCause) Unwrap() error {
+	return w.cause
+}
+
+func (w *withCause) Cause() error {
+	return Unwrap(w)
+}
+
+func (w *withCause) Is(target error) bool {
+	return is(w.outer, target)
+}
+
+func (w *withCause) As(target interface{}) bool {
+	return As(w.outer, target)
+}
+
+func (w *withCause) Wrap(message string, args ...interface{}) *withMessage {
+	return newMessage(w, message, args...)
+}
+
+func (w *withCause) Level(level syslog.Level) *withLevel {
+	return withLevelAt(w, level, 1)
+}
+
+func (w *withCause) Status(status int) *withStatus {
+	return WithStatus(w, status)
+}
+
+func (w *withCause) Alert() *withAlert {
+	return &withAlert{w}
+}
+
+func (w *withCause) With(key string, value interface{}) *withField {
+	return &withField{cause: w, key: key, value: value}
+}
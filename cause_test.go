@@ -0,0 +1,49 @@
+package errors
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	net "net/http"
+	"testing"
+)
+
+func Test_wrap_cause_nil_outer(t *testing.T) {
+	assert.Nil(t, WrapCause(nil, io.EOF))
+}
+
+func Test_wrap_cause_nil_cause(t *testing.T) {
+	outer := New("cannot save order")
+	assert.Equal(t, outer, WrapCause(outer, nil))
+}
+
+func Test_wrap_cause_keeps_outer_message(t *testing.T) {
+	err := WrapCause(New("cannot save order"), io.EOF)
+
+	assert.Equal(t, "cannot save order", err.Error())
+	assert.Equal(t, "cannot save order", fmt.Sprintf("%s", err))
+}
+
+func Test_wrap_cause_unwraps_to_cause(t *testing.T) {
+	err := WrapCause(New("cannot save order"), io.EOF)
+
+	assert.Equal(t, io.EOF, err.(unwrapper).Unwrap())
+	assert.Equal(t, io.EOF, Unwrap(err))
+	assert.True(t, Is(err, io.EOF))
+}
+
+func Test_wrap_cause_matches_outer(t *testing.T) {
+	outer := New("cannot save order").Status(net.StatusConflict)
+	err := WrapCause(outer, io.EOF)
+
+	status, ok := FindStatus(err)
+	assert.True(t, ok)
+	assert.Equal(t, net.StatusConflict, status)
+	assert.True(t, Is(err, outer))
+}
+
+func Test_wrap_cause_extended_format(t *testing.T) {
+	err := WrapCause(New("cannot save order"), io.EOF)
+
+	assert.Contains(t, fmt.Sprintf("%+v", err), "caused by: EOF")
+}
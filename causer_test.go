@@ -0,0 +1,67 @@
+package errors
+
+import (
+	"github.com/confetti-framework/syslog/log_level"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+type causer interface {
+	Cause() error
+}
+
+// pkgCause mirrors Cause of github.com/pkg/errors.
+func pkgCause(err error) error {
+	for err != nil {
+		cause, ok := err.(causer)
+		if !ok {
+			break
+		}
+		err = cause.Cause()
+	}
+	return err
+}
+
+func Test_cause_returns_root(t *testing.T) {
+	tests := []error{
+		Wrap(io.EOF, "read"),
+		WithMessage(io.EOF, "read"),
+		WithStack(io.EOF),
+		WithLevel(io.EOF, log_level.ERROR),
+		WithStatus(io.EOF, 500),
+		WithAlert(io.EOF),
+		WithCode(io.EOF, "E1"),
+		WithNumericCode(io.EOF, 1),
+		WithField(io.EOF, "id", 1),
+		WithOp(io.EOF, "LoadUser"),
+		WithRetryable(io.EOF),
+		WithLogged(io.EOF),
+		Handoff(io.EOF),
+		WithCategory(io.EOF, "db"),
+		WithHint(io.EOF, "retry"),
+		WrapLazy(io.EOF, func() string { return "read" }),
+		WithTime(io.EOF),
+		WithDiagnostic(io.EOF, "request", nil),
+		WithHeaders(io.EOF, nil),
+		WithRetryAfter(io.EOF, 0),
+		WithPublicMessage(io.EOF, "sorry"),
+		WithExplicitStack(io.EOF, nil),
+		WrapCause(New("outer"), io.EOF),
+		New("outer").Because(io.EOF),
+		WithStatus(Wrap(WithCode(io.EOF, "E1"), "read"), 500),
+	}
+	for _, err := range tests {
+		c, ok := err.(causer)
+		if assert.True(t, ok, "%T has no Cause method", err) {
+			assert.Equal(t, io.EOF, c.Cause())
+		}
+		assert.Equal(t, io.EOF, pkgCause(err))
+	}
+}
+
+func Test_cause_of_validation(t *testing.T) {
+	err := NewValidation(map[string]string{"name": "required"})
+
+	assert.Equal(t, Unwrap(err), err.(causer).Cause())
+}
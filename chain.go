@@ -0,0 +1,173 @@
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Links returns every distinct error value in the chain of err, from the
+// outermost to the innermost, so callers can type switch on each of them.
+// Errors that unwrap to multiple errors (Unwrap() []error) are flattened
+// depth first, in order. Links returns nil if err is nil.
+func Links(err error) []error {
+	var links []error
+	var walk func(err error)
+	walk = func(err error) {
+		for err != nil {
+			if containsError(links, err) {
+				return
+			}
+			links = append(links, err)
+			switch unwrapper := err.(type) {
+			case interface{ Unwrap() []error }:
+				for _, child := range unwrapper.Unwrap() {
+					walk(child)
+				}
+				return
+			case interface{ Unwrap() error }:
+				err = unwrapper.Unwrap()
+			default:
+				return
+			}
+		}
+	}
+	walk(err)
+	return links
+}
+
+// walkAsOrder calls fn for every error in the chain of err, in the order in
+// which As inspects them: unlike Links, it also visits the outer error of
+// WrapCause, before the cause.
+func walkAsOrder(err error, fn func(err error)) {
+	for err != nil {
+		fn(err)
+		switch layer := err.(type) {
+		case *withCause:
+			walkAsOrder(layer.outer, fn)
+			err = layer.cause
+		case interface{ Unwrap() []error }:
+			for _, child := range layer.Unwrap() {
+				walkAsOrder(child, fn)
+			}
+			return
+		case interface{ Unwrap() error }:
+			err = layer.Unwrap()
+		default:
+			return
+		}
+	}
+}
+
+// WalkTree calls fn for every error in the tree of err, breadth first, with
+// its depth: 0 for err itself, 1 for the errors it unwraps to, and so on.
+// Errors that unwrap to multiple errors (Unwrap() []error) have a child for
+// each of them, in order. The walk stops as soon as fn returns false.
+func WalkTree(err error, fn func(err error, depth int) bool) {
+	type node struct {
+		err   error
+		depth int
+	}
+	var queue []node
+	if err != nil {
+		queue = append(queue, node{err, 0})
+	}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if !fn(current.err, current.depth) {
+			return
+		}
+		switch unwrapper := current.err.(type) {
+		case interface{ Unwrap() []error }:
+			for _, child := range unwrapper.Unwrap() {
+				if child != nil {
+					queue = append(queue, node{child, current.depth + 1})
+				}
+			}
+		case interface{ Unwrap() error }:
+			if child := unwrapper.Unwrap(); child != nil {
+				queue = append(queue, node{child, current.depth + 1})
+			}
+		}
+	}
+}
+
+// containsError reports whether err is already present in errs.
+func containsError(errs []error, err error) bool {
+	for _, e := range errs {
+		if sameError(e, err) {
+			return true
+		}
+	}
+	return false
+}
+
+// sameError reports whether a and b are the identical error value. Errors of
+// incomparable types, and nil errors, are never the same.
+func sameError(a, b error) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	if !reflect.TypeOf(a).Comparable() || reflect.TypeOf(a) != reflect.TypeOf(b) {
+		return false
+	}
+	return a == b
+}
+
+// FindFunc returns the first layer in the chain of err, as returned by
+// Links, for which pred returns true:
+//
+//	timeout, ok := errors.FindFunc(err, func(layer error) bool {
+//	        return strings.Contains(layer.Error(), "timeout")
+//	})
+func FindFunc(err error, pred func(error) bool) (error, bool) {
+	for _, link := range Links(err) {
+		if pred(link) {
+			return link, true
+		}
+	}
+	return nil, false
+}
+
+// SameRoot reports whether a and b have the same root cause, as returned by
+// Unwrap. The root causes match if they are identical, or if one matches
+// the other using Is. SameRoot returns false if a or b is nil, or if
+// either chain ends in a nil cause.
+func SameRoot(a, b error) bool {
+	if a == nil || b == nil {
+		return false
+	}
+	rootA, rootB := Unwrap(a), Unwrap(b)
+	if rootA == nil || rootB == nil {
+		return false
+	}
+	if sameError(rootA, rootB) {
+		return true
+	}
+	return is(rootA, rootB) || is(rootB, rootA)
+}
+
+// SprintChain returns a line for every layer in the chain of err, from the
+// outermost to the innermost, holding its index, its type and the message
+// it adds, if any:
+//
+//	0: *errors.withStack
+//	1: *errors.withMessage: load user
+//	2: *errors.fundamental: row not found
+//
+// It is shorter than %+v, but shows the structure of err.
+func SprintChain(err error) string {
+	var b strings.Builder
+	for i, link := range Links(err) {
+		if i > 0 {
+			b.WriteByte('\n')
+		}
+		fmt.Fprintf(&b, "%d: %T", i, link)
+		if message, _ := splitMessage(link); message != "" {
+			b.WriteString(": ")
+			b.WriteString(message)
+		}
+	}
+	return b.String()
+}
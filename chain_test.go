@@ -0,0 +1,206 @@
+package errors
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	net "net/http"
+	"strings"
+	"testing"
+)
+
+type multiError []error
+
+func (m multiError) Error() string   { return "multiple errors" }
+func (m multiError) Unwrap() []error { return m }
+
+func Test_links_nil(t *testing.T) {
+	assert.Nil(t, Links(nil))
+}
+
+func Test_links_single(t *testing.T) {
+	assert.Equal(t, []error{io.EOF}, Links(io.EOF))
+}
+
+func Test_links_outer_to_inner(t *testing.T) {
+	err := Wrap(New("not found").Status(net.StatusNotFound), "database error")
+
+	links := Links(err)
+	assert.Len(t, links, 4)
+	assert.IsType(t, &withStack{}, links[0])
+	assert.IsType(t, &withMessage{}, links[1])
+	assert.IsType(t, &withStatus{}, links[2])
+	assert.IsType(t, &fundamental{}, links[3])
+}
+
+func Test_links_flattens_multi_errors(t *testing.T) {
+	first := Wrap(io.EOF, "first")
+	second := New("second")
+	err := WithStack(multiError{first, second})
+
+	links := Links(err)
+	assert.Len(t, links, 6)
+	assert.IsType(t, &withStack{}, links[0])
+	assert.IsType(t, multiError{}, links[1])
+	assert.Equal(t, first, links[2])
+	assert.IsType(t, &withMessage{}, links[3])
+	assert.Equal(t, io.EOF, links[4])
+	assert.Equal(t, second, links[5])
+}
+
+func Test_links_distinct(t *testing.T) {
+	err := multiError{io.EOF, Wrap(io.EOF, "read failed")}
+
+	links := Links(err)
+	assert.Len(t, links, 4)
+	assert.Equal(t, io.EOF, links[1])
+	assert.IsType(t, &withMessage{}, links[3])
+}
+
+func Test_same_root_nil(t *testing.T) {
+	assert.False(t, SameRoot(nil, io.EOF))
+	assert.False(t, SameRoot(io.EOF, nil))
+	assert.False(t, SameRoot(nil, nil))
+}
+
+func Test_same_root_nil_root(t *testing.T) {
+	assert.False(t, SameRoot(io.EOF, WithMessage(nil, "x")))
+	assert.False(t, SameRoot(WithMessage(nil, "x"), io.EOF))
+	assert.False(t, SameRoot(WithMessage(nil, "x"), WithMessage(nil, "y")))
+}
+
+func Test_same_root_wrapping_same_cause(t *testing.T) {
+	a := Wrap(io.EOF, "read header")
+	b := WithStatus(Wrap(io.EOF, "read body"), net.StatusBadGateway)
+
+	assert.True(t, SameRoot(a, b))
+	assert.True(t, SameRoot(a, io.EOF))
+}
+
+func Test_same_root_different_causes(t *testing.T) {
+	a := Wrap(io.EOF, "read header")
+	b := Wrap(io.ErrUnexpectedEOF, "read header")
+
+	assert.False(t, SameRoot(a, b))
+}
+
+func Test_same_root_equal_messages(t *testing.T) {
+	assert.False(t, SameRoot(New("not found"), New("not found")))
+}
+
+type sentinelMatcher struct{}
+
+func (sentinelMatcher) Error() string        { return "matches EOF" }
+func (sentinelMatcher) Is(target error) bool { return target == io.EOF }
+
+func Test_same_root_using_is(t *testing.T) {
+	assert.True(t, SameRoot(Wrap(sentinelMatcher{}, "read"), Wrap(io.EOF, "read")))
+}
+
+func Test_sprint_chain_nil(t *testing.T) {
+	assert.Equal(t, "", SprintChain(nil))
+}
+
+func Test_sprint_chain(t *testing.T) {
+	err := WithStatus(Wrap(New("row not found"), "load user"), net.StatusNotFound)
+
+	assert.Equal(t, "0: *errors.withStatus\n"+
+		"1: *errors.withStack\n"+
+		"2: *errors.withMessage: load user\n"+
+		"3: *errors.fundamental: row not found", SprintChain(err))
+}
+
+func Test_sprint_chain_line_per_layer(t *testing.T) {
+	err := Wrap(fmt.Errorf("query users: %w", io.EOF), "load user")
+
+	lines := strings.Split(SprintChain(err), "\n")
+	assert.Len(t, lines, len(Links(err)))
+	assert.Equal(t, "2: *fmt.wrapError: query users", lines[2])
+	assert.Equal(t, "3: *errors.errorString: EOF", lines[3])
+}
+
+func Test_walk_tree_nil(t *testing.T) {
+	WalkTree(nil, func(err error, depth int) bool {
+		t.Fatal("fn must not be called")
+		return true
+	})
+}
+
+func Test_walk_tree_breadth_first(t *testing.T) {
+	first := New("first")
+	second := New("second")
+	third := New("third")
+	nested := Join(second, third)
+	err := Join(first, nested)
+
+	var errs []error
+	var depths []int
+	WalkTree(err, func(err error, depth int) bool {
+		errs = append(errs, err)
+		depths = append(depths, depth)
+		return true
+	})
+
+	assert.Equal(t, []error{err, first, nested, second, third}, errs)
+	assert.Equal(t, []int{0, 1, 1, 2, 2}, depths)
+}
+
+func Test_walk_tree_wrapped_layers(t *testing.T) {
+	err := Join(Wrap(io.EOF, "read"), New("second"))
+
+	var depths []int
+	WalkTree(err, func(err error, depth int) bool {
+		if err == io.EOF {
+			depths = append(depths, depth)
+		}
+		return true
+	})
+
+	assert.Equal(t, []int{3}, depths)
+}
+
+func Test_walk_tree_stops(t *testing.T) {
+	err := Join(New("first"), New("second"))
+
+	calls := 0
+	WalkTree(err, func(err error, depth int) bool {
+		calls++
+		return depth == 0
+	})
+
+	assert.Equal(t, 2, calls)
+}
+
+func Test_find_func_first_match(t *testing.T) {
+	err := Wrap(WithStatus(Wrap(io.EOF, "read timeout"), net.StatusGatewayTimeout), "load user")
+
+	layer, ok := FindFunc(err, func(layer error) bool {
+		return strings.HasPrefix(layer.Error(), "read timeout")
+	})
+
+	assert.True(t, ok)
+	assert.IsType(t, &withStatus{}, layer)
+}
+
+func Test_find_func_by_type(t *testing.T) {
+	err := Wrap(WithCode(io.EOF, "E1"), "read")
+
+	layer, ok := FindFunc(err, func(layer error) bool {
+		_, isCode := layer.(*withCode)
+		return isCode
+	})
+
+	assert.True(t, ok)
+	assert.Equal(t, "EOF", layer.Error())
+}
+
+func Test_find_func_without_match(t *testing.T) {
+	never := func(error) bool { return false }
+
+	layer, ok := FindFunc(Wrap(io.EOF, "read"), never)
+	assert.False(t, ok)
+	assert.Nil(t, layer)
+
+	_, ok = FindFunc(nil, func(error) bool { return true })
+	assert.False(t, ok)
+}
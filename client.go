@@ -0,0 +1,42 @@
+package errors
+
+// requestIDField is the field ClientError keeps to let clients refer to a
+// failed request.
+const requestIDField = "request_id"
+
+// ClientError returns a new error that is safe to serialize for untrusted
+// clients. It keeps only the status, the codes, the public message, the
+// messages per field of a validation error and the field "request_id" of
+// err, and drops its stack traces, internal messages, other fields and
+// level. The message of the result is the public message,
+// or the status text without one. If err is nil, ClientError returns nil.
+func ClientError(err error) error {
+	if err == nil {
+		return nil
+	}
+	public, hasPublic := FindPublicMessage(err)
+	message := public
+	if !hasPublic {
+		message = StatusText(err)
+	}
+	var result error = &fundamental{msg: message, stack: emptyStack}
+	if hasPublic {
+		result = WithPublicMessage(result, public)
+	}
+	if fieldErrors, ok := ValidationErrors(err); ok {
+		result = &withValidation{cause: result, fields: fieldErrors}
+	}
+	if requestID, ok := findField(err, requestIDField); ok {
+		result = WithField(result, requestIDField, requestID)
+	}
+	if code, ok := FindNumericCode(err); ok {
+		result = WithNumericCode(result, code)
+	}
+	if code, ok := FindCode(err); ok {
+		result = WithCode(result, code)
+	}
+	if status, ok := FindStatus(err); ok {
+		result = WithStatus(result, status)
+	}
+	return result
+}
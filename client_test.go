@@ -0,0 +1,63 @@
+package errors
+
+import (
+	"fmt"
+	"github.com/confetti-framework/syslog/log_level"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_client_error_nil(t *testing.T) {
+	assert.Nil(t, ClientError(nil))
+}
+
+func Test_client_error_keeps_public_metadata(t *testing.T) {
+	var err error = Wrap(New("select from users: connection refused"), "load user")
+	err = WithPublicMessage(WithField(err, "query", "select"), "user %d not available", 12)
+	err = WithField(WithCode(err, "E1"), "request_id", "abc")
+	err = WithLevel(WithStatus(err, 404), log_level.CRITICAL)
+
+	client := ClientError(err)
+
+	assert.EqualError(t, client, "user 12 not available")
+	public, ok := FindPublicMessage(client)
+	assert.True(t, ok)
+	assert.Equal(t, "user 12 not available", public)
+	status, ok := FindStatus(client)
+	assert.True(t, ok)
+	assert.Equal(t, 404, status)
+	code, ok := FindCode(client)
+	assert.True(t, ok)
+	assert.Equal(t, "E1", code)
+	assert.Equal(t, map[string]interface{}{"request_id": "abc"}, FindFields(client))
+}
+
+func Test_client_error_drops_internals(t *testing.T) {
+	err := WithLevel(WithField(Wrap(New("connection refused"), "load user"), "query", "select"), log_level.ERROR)
+
+	client := ClientError(WithStatus(err, 503))
+
+	assert.EqualError(t, client, "Service Unavailable")
+	assert.NotContains(t, fmt.Sprintf("%+v", client), "connection refused")
+	_, ok := FindStack(client)
+	assert.False(t, ok)
+	_, ok = FindLevel(client)
+	assert.False(t, ok)
+	assert.Empty(t, FindFields(client))
+}
+
+func Test_client_error_without_status(t *testing.T) {
+	client := ClientError(New("connection refused"))
+
+	assert.EqualError(t, client, "Internal Server Error")
+	_, ok := FindStatus(client)
+	assert.False(t, ok)
+}
+
+func Test_client_error_keeps_validation_errors(t *testing.T) {
+	err := Wrap(NewValidation(map[string]string{"email": "is required"}), "register user")
+
+	fieldErrors, ok := ValidationErrors(ClientError(err))
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"email": "is required"}, fieldErrors)
+}
@@ -0,0 +1,30 @@
+package errors
+
+import (
+	"sync"
+	"time"
+)
+
+var (
+	clockMu sync.RWMutex
+	clock   = time.Now
+)
+
+// SetClock sets the function used to read the current time, so tests can
+// make time based annotations deterministic. A nil function restores
+// time.Now.
+func SetClock(now func() time.Time) {
+	if now == nil {
+		now = time.Now
+	}
+	clockMu.Lock()
+	defer clockMu.Unlock()
+	clock = now
+}
+
+func currentTime() time.Time {
+	clockMu.RLock()
+	now := clock
+	clockMu.RUnlock()
+	return now()
+}
@@ -0,0 +1,21 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func Test_set_clock(t *testing.T) {
+	fixed := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	SetClock(func() time.Time { return fixed })
+	defer SetClock(nil)
+
+	assert.Equal(t, fixed, currentTime())
+}
+
+func Test_set_clock_nil_restores_time_now(t *testing.T) {
+	SetClock(nil)
+
+	assert.WithinDuration(t, time.Now(), currentTime(), time.Minute)
+}
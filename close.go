@@ -0,0 +1,32 @@
+package errors
+
+import (
+	"io"
+)
+
+// CloseWith closes closer and records a failure to close in err. It is meant
+// to be deferred by functions with a named error result:
+//
+//	func read(name string) (err error) {
+//	        f, err := os.Open(name)
+//	        if err != nil {
+//	                return err
+//	        }
+//	        defer errors.CloseWith(&err, f, "close %s", name)
+//	        ...
+//	}
+//
+// If Close fails, the close error is wrapped with message. It is stored in
+// err when *err is nil, and joined with *err otherwise.
+func CloseWith(err *error, closer io.Closer, message string, args ...interface{}) {
+	closeErr := closer.Close()
+	if closeErr == nil {
+		return
+	}
+	wrapped := newWrap(closeErr, callers(), message, args...)
+	if *err == nil {
+		*err = wrapped
+		return
+	}
+	*err = Join(*err, wrapped)
+}
@@ -0,0 +1,47 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+type closer struct {
+	err error
+}
+
+func (c closer) Close() error {
+	return c.err
+}
+
+func readAndClose(c io.Closer, readErr error) (err error) {
+	defer CloseWith(&err, c, "close %s", "file")
+	return readErr
+}
+
+func Test_close_with_success(t *testing.T) {
+	assert.Nil(t, readAndClose(closer{}, nil))
+}
+
+func Test_close_with_success_keeps_error(t *testing.T) {
+	assert.Equal(t, io.EOF, readAndClose(closer{}, io.EOF))
+}
+
+func Test_close_with_failure_and_nil_error(t *testing.T) {
+	err := readAndClose(closer{io.ErrClosedPipe}, nil)
+
+	assert.Equal(t, "close file: io: read/write on closed pipe", err.Error())
+	assert.True(t, Is(err, io.ErrClosedPipe))
+
+	stack, ok := FindStack(err)
+	assert.True(t, ok)
+	assert.Equal(t, "readAndClose", funcname(stack[0].name()))
+}
+
+func Test_close_with_failure_and_error(t *testing.T) {
+	err := readAndClose(closer{io.ErrClosedPipe}, io.EOF)
+
+	assert.Equal(t, "EOF\nclose file: io: read/write on closed pipe", err.Error())
+	assert.True(t, Is(err, io.EOF))
+	assert.True(t, Is(err, io.ErrClosedPipe))
+}
@@ -0,0 +1,71 @@
+package errors
+
+import (
+	"fmt"
+	syslog "github.com/confetti-framework/syslog/log_level"
+)
+
+// WithCode annotates err with an application error code such as "E1001" or
+// "user_not_found". Codes are meant to be stable identifiers that do not
+// change when the message is reworded. If err is nil, WithCode returns nil.
+func WithCode(err error, code string) error {
+	if err == nil {
+		return nil
+	}
+	return &withCode{
+		err,
+		code,
+	}
+}
+
+// FindCode returns the outermost code in the chain of err.
+func FindCode(err error) (string, bool) {
+	var codeHolder *withCode
+
+	if !As(err, &codeHolder) {
+		return "", false
+	}
+
+	return codeHolder.code, true
+}
+
+type withCode struct {
+	cause error
+	code  string
+}
+
+func (w *withCode) Error() string {
+	return w.cause.Error()
+}
+
+func (w *withCode) Format(st fmt.State, verb rune) {
+	formatCause(st, verb, w, w.cause)
+}
+
+func (w *withCode) Unwrap() error {
+	return w.cause
+}
+
+func (w *withCode) Cause() error {
+	return Unwrap(w)
+}
+
+func (w *withCode) Wrap(message string, args ...interface{}) *withMessage {
+	return newMessage(w, message, args...)
+}
+
+func (w *withCode) Level(level syslog.Level) *withLevel {
+	return withLevelAt(w, level, 1)
+}
+
+func (w *withCode) Status(status int) *withStatus {
+	return WithStatus(w, status)
+}
+
+func (w *withCode) Alert() *withAlert {
+	return &withAlert{w}
+}
+
+func (w *withCode) With(key string, value interface{}) *withField {
+	return &withField{cause: w, key: key, value: value}
+}
@@ -0,0 +1,33 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func Test_code_with_nil(t *testing.T) {
+	assert.Nil(t, WithCode(nil, "E1"))
+}
+
+func Test_code_without_code(t *testing.T) {
+	code, ok := FindCode(New("not found"))
+	assert.False(t, ok)
+	assert.Equal(t, "", code)
+}
+
+func Test_code_from_unwrap(t *testing.T) {
+	err := Wrap(WithCode(New("not found"), "E1"), "database error")
+
+	code, ok := FindCode(err)
+	assert.True(t, ok)
+	assert.Equal(t, "E1", code)
+	assert.Equal(t, "database error: not found", err.Error())
+}
+
+func Test_code_keeps_cause(t *testing.T) {
+	err := WithCode(io.EOF, "E1")
+
+	assert.True(t, Is(err, io.EOF))
+	assert.Equal(t, "EOF", err.Error())
+}
@@ -0,0 +1,57 @@
+package errors
+
+// Errors collects errors in the order they are appended. The zero value is
+// an empty collection ready to use:
+//
+//	var errs errors.Errors
+//	for _, item := range items {
+//	        errs.Append(process(item))
+//	}
+//	return errs.ErrorOrNil()
+type Errors struct {
+	errs []error
+}
+
+// Append adds err to the collection. Nil errors are discarded.
+func (e *Errors) Append(err error) {
+	if err != nil {
+		e.errs = append(e.errs, err)
+	}
+}
+
+// Len returns the number of collected errors.
+func (e *Errors) Len() int {
+	return len(e.errs)
+}
+
+// ErrorOrNil returns nil if the collection is empty and the error itself if
+// exactly one error was collected. Otherwise it returns the collected errors
+// joined as with Join. Errors appended afterwards do not affect the returned
+// error.
+func (e *Errors) ErrorOrNil() error {
+	switch len(e.errs) {
+	case 0:
+		return nil
+	case 1:
+		return e.errs[0]
+	default:
+		return Join(e.errs...)
+	}
+}
+
+// Error returns the messages of the collected errors, separated by newlines.
+func (e *Errors) Error() string {
+	return (&joinError{errs: e.errs}).Error()
+}
+
+func (e *Errors) Unwrap() []error {
+	return e.errs
+}
+
+func (e *Errors) Is(target error) bool {
+	return (&joinError{errs: e.errs}).Is(target)
+}
+
+func (e *Errors) As(target interface{}) bool {
+	return (&joinError{errs: e.errs}).As(target)
+}
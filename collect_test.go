@@ -0,0 +1,49 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func Test_errors_empty(t *testing.T) {
+	var errs Errors
+	errs.Append(nil)
+
+	assert.Equal(t, 0, errs.Len())
+	assert.Nil(t, errs.ErrorOrNil())
+	assert.Equal(t, "", errs.Error())
+}
+
+func Test_errors_single(t *testing.T) {
+	var errs Errors
+	errs.Append(io.EOF)
+
+	assert.Equal(t, 1, errs.Len())
+	assert.Equal(t, io.EOF, errs.ErrorOrNil())
+}
+
+func Test_errors_multiple(t *testing.T) {
+	var errs Errors
+	errs.Append(New("first"))
+	errs.Append(nil)
+	errs.Append(Wrap(io.EOF, "second"))
+
+	assert.Equal(t, 2, errs.Len())
+	assert.Equal(t, "first\nsecond: EOF", errs.Error())
+
+	err := errs.ErrorOrNil()
+	assert.Equal(t, "first\nsecond: EOF", err.Error())
+	assert.True(t, Is(err, io.EOF))
+	assert.True(t, Is(&errs, io.EOF))
+}
+
+func Test_errors_or_nil_is_not_affected_by_later_appends(t *testing.T) {
+	var errs Errors
+	errs.Append(New("first"))
+	errs.Append(New("second"))
+	err := errs.ErrorOrNil()
+	errs.Append(New("third"))
+
+	assert.Equal(t, "first\nsecond", err.Error())
+}
@@ -0,0 +1,22 @@
+package errors
+
+import (
+	"sync/atomic"
+)
+
+var debugMode int32
+
+// SetDebugMode controls whether output meant for clients, such as the page
+// of RenderHTML, includes internal messages and stack traces. It is off by
+// default and must not be turned on in production.
+func SetDebugMode(enabled bool) {
+	var mode int32
+	if enabled {
+		mode = 1
+	}
+	atomic.StoreInt32(&debugMode, mode)
+}
+
+func debugModeEnabled() bool {
+	return atomic.LoadInt32(&debugMode) == 1
+}
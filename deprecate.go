@@ -0,0 +1,104 @@
+package errors
+
+import (
+	stderrors "errors"
+	syslog "github.com/confetti-framework/syslog/log_level"
+	"sync"
+	"sync/atomic"
+)
+
+type deprecation struct {
+	old         error
+	replacement error
+	message     string
+	// warned is shared with the entry that replaces this one, so the
+	// warning is logged once per sentinel.
+	warned *sync.Once
+}
+
+var (
+	deprecationMu   sync.RWMutex
+	deprecations    []*deprecation
+	hasDeprecations int32
+)
+
+// Deprecate marks the sentinel old as replaced by replacement, to migrate
+// code that returns old to return replacement instead. Is(err, old) keeps
+// reporting true for errors that match replacement, so callers that still
+// check for old keep working. The first time Is matches old, message is
+// logged at WARNING to the logger configured with SetLogger. Deprecating
+// the same sentinel again replaces its replacement and message, but does not
+// log the warning again.
+func Deprecate(old, replacement error, message string) {
+	if old == nil || replacement == nil {
+		return
+	}
+	deprecationMu.Lock()
+	defer deprecationMu.Unlock()
+	for i, entry := range deprecations {
+		if sameError(entry.old, old) {
+			deprecations[i] = &deprecation{old, replacement, message, entry.warned}
+			return
+		}
+	}
+	deprecations = append(deprecations, &deprecation{old, replacement, message, &sync.Once{}})
+	atomic.StoreInt32(&hasDeprecations, 1)
+}
+
+// resetDeprecations removes all deprecations.
+func resetDeprecations() {
+	deprecationMu.Lock()
+	defer deprecationMu.Unlock()
+	deprecations = nil
+	atomic.StoreInt32(&hasDeprecations, 0)
+}
+
+// deprecationOf returns the deprecation registered for target.
+func deprecationOf(target error) (*deprecation, bool) {
+	if target == nil || atomic.LoadInt32(&hasDeprecations) == 0 {
+		return nil, false
+	}
+	deprecationMu.RLock()
+	defer deprecationMu.RUnlock()
+	for _, entry := range deprecations {
+		if sameError(entry.old, target) {
+			return entry, true
+		}
+	}
+	return nil, false
+}
+
+// matchDeprecated reports whether err matches the replacement of target,
+// if target is deprecated. matched reports whether err matches target
+// itself. If err matches a deprecated target, its deprecation is returned
+// as well, so the caller can warn about it.
+func matchDeprecated(err error, target error, matched bool) (bool, *deprecation) {
+	entry, ok := deprecationOf(target)
+	if !ok {
+		return matched, nil
+	}
+	if !matched {
+		matched = stderrors.Is(err, entry.replacement)
+	}
+	if !matched {
+		return false, nil
+	}
+	return true, entry
+}
+
+// warn logs the message of the deprecation once. Without a configured
+// logger nothing is logged, and the warning remains pending. The logger is
+// called after the warning is marked as logged, so it may call Is itself.
+func (d *deprecation) warn() {
+	logger := currentLogger()
+	if logger == nil {
+		return
+	}
+	first := false
+	d.warned.Do(func() {
+		first = true
+	})
+	if first {
+		logger.Log(syslog.WARNING, d.message)
+	}
+}
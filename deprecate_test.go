@@ -0,0 +1,114 @@
+package errors
+
+import (
+	"github.com/confetti-framework/syslog/log_level"
+	"github.com/stretchr/testify/assert"
+	net "net/http"
+	"testing"
+)
+
+func Test_deprecated_sentinel_matches_replacement(t *testing.T) {
+	t.Cleanup(resetDeprecations)
+	errOld := New("old not found")
+	errNew := New("not found")
+	Deprecate(errOld, errNew, "errOld is deprecated, use errNew")
+
+	err := Wrap(errNew, "load user")
+
+	assert.True(t, Is(err, errOld))
+	assert.True(t, Is(err, errNew))
+	assert.False(t, Is(New("other"), errOld))
+	assert.False(t, Is(err, nil))
+}
+
+func Test_deprecated_sentinel_warns_once(t *testing.T) {
+	t.Cleanup(resetDeprecations)
+	logger := &fakeLogger{}
+	SetLogger(logger)
+	defer SetLogger(nil)
+	errOld := New("old not found")
+	errNew := New("not found")
+	Deprecate(errOld, errNew, "errOld is deprecated, use errNew")
+
+	assert.False(t, Is(New("other"), errOld))
+	assert.Empty(t, logger.entries)
+
+	assert.True(t, Is(Wrap(errNew, "load user"), errOld))
+	assert.True(t, Is(errOld, errOld))
+	assert.True(t, Is(errNew, errOld))
+	assert.Equal(t, []logEntry{{log_level.WARNING, "errOld is deprecated, use errNew"}}, logger.entries)
+}
+
+func Test_deprecated_sentinel_warns_after_logger_is_set(t *testing.T) {
+	t.Cleanup(resetDeprecations)
+	errOld := New("old not found")
+	errNew := New("not found")
+	Deprecate(errOld, errNew, "errOld is deprecated, use errNew")
+	assert.True(t, Is(errNew, errOld))
+
+	logger := &fakeLogger{}
+	SetLogger(logger)
+	defer SetLogger(nil)
+	assert.True(t, Is(errNew, errOld))
+	assert.True(t, Is(errNew, errOld))
+
+	assert.Len(t, logger.entries, 1)
+}
+
+func Test_deprecated_sentinel_deprecated_again_warns_once(t *testing.T) {
+	t.Cleanup(resetDeprecations)
+	logger := &fakeLogger{}
+	SetLogger(logger)
+	defer SetLogger(nil)
+	errOld := New("old not found")
+	errNew := New("not found")
+	Deprecate(errOld, errNew, "errOld is deprecated, use errNew")
+	assert.True(t, Is(errNew, errOld))
+
+	errNewer := New("not found, again")
+	Deprecate(errOld, errNewer, "errOld is deprecated, use errNewer")
+
+	assert.True(t, Is(errNewer, errOld))
+	assert.False(t, Is(errNew, errOld))
+	assert.Len(t, logger.entries, 1)
+}
+
+func Test_deprecated_sentinel_internal_lookup_does_not_warn(t *testing.T) {
+	t.Cleanup(resetDeprecations)
+	logger := &fakeLogger{}
+	SetLogger(logger)
+	defer SetLogger(nil)
+	errOld := New("old not found")
+	errNew := New("not found")
+	Deprecate(errOld, errNew, "errOld is deprecated, use errNew")
+	RegisterStatus(errOld, net.StatusNotFound)
+
+	status, ok := FindStatus(Wrap(errNew, "load user"))
+
+	assert.True(t, ok)
+	assert.Equal(t, net.StatusNotFound, status)
+	assert.Empty(t, logger.entries)
+}
+
+type reentrantLogger struct {
+	fakeLogger
+	check func()
+}
+
+func (l *reentrantLogger) Log(level log_level.Level, message string) {
+	l.check()
+	l.fakeLogger.Log(level, message)
+}
+
+func Test_deprecated_sentinel_logger_calls_is(t *testing.T) {
+	t.Cleanup(resetDeprecations)
+	errOld := New("old not found")
+	errNew := New("not found")
+	Deprecate(errOld, errNew, "errOld is deprecated, use errNew")
+	logger := &reentrantLogger{check: func() { Is(errNew, errOld) }}
+	SetLogger(logger)
+	defer SetLogger(nil)
+
+	assert.True(t, Is(errNew, errOld))
+	assert.Len(t, logger.entries, 1)
+}
@@ -0,0 +1,78 @@
+package errors
+
+import (
+	"fmt"
+	syslog "github.com/confetti-framework/syslog/log_level"
+)
+
+// WithDiagnostic annotates err with opaque diagnostic data under name, such
+// as a dump of a request or a reference to a heap profile. The data is left
+// out of the message, %+v and JSON, but FindDiagnostics returns it, e.g. to
+// build an incident report. If err is nil, WithDiagnostic returns nil.
+func WithDiagnostic(err error, name string, data []byte) error {
+	if err == nil {
+		return nil
+	}
+	return &withDiagnostic{
+		cause: err,
+		name:  name,
+		data:  data,
+	}
+}
+
+// FindDiagnostics returns all diagnostic data in the chain of err by name.
+// When a name is used on multiple layers, the outermost data wins.
+// FindDiagnostics returns an empty map if no diagnostic data is present.
+func FindDiagnostics(err error) map[string][]byte {
+	diagnostics := map[string][]byte{}
+	for _, link := range Links(err) {
+		if diagnostic, ok := link.(*withDiagnostic); ok {
+			if _, exists := diagnostics[diagnostic.name]; !exists {
+				diagnostics[diagnostic.name] = diagnostic.data
+			}
+		}
+	}
+	return diagnostics
+}
+
+type withDiagnostic struct {
+	cause error
+	name  string
+	data  []byte
+}
+
+func (w *withDiagnostic) Error() string {
+	return w.cause.Error()
+}
+
+func (w *withDiagnostic) Format(st fmt.State, verb rune) {
+	formatCause(st, verb, w, w.cause)
+}
+
+func (w *withDiagnostic) Unwrap() error {
+	return w.cause
+}
+
+func (w *withDiagnostic) Cause() error {
+	return Unwrap(w)
+}
+
+func (w *withDiagnostic) Wrap(message string, args ...interface{}) *withMessage {
+	return newMessage(w, message, args...)
+}
+
+func (w *withDiagnostic) Level(level syslog.Level) *withLevel {
+	return withLevelAt(w, level, 1)
+}
+
+func (w *withDiagnostic) Status(status int) *withStatus {
+	return WithStatus(w, status)
+}
+
+func (w *withDiagnostic) Alert() *withAlert {
+	return &withAlert{w}
+}
+
+func (w *withDiagnostic) With(key string, value interface{}) *withField {
+	return &withField{cause: w, key: key, value: value}
+}
@@ -0,0 +1,46 @@
+package errors
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func Test_with_diagnostic_nil(t *testing.T) {
+	assert.Nil(t, WithDiagnostic(nil, "request", []byte("GET / HTTP/1.1")))
+}
+
+func Test_find_diagnostics(t *testing.T) {
+	dump := []byte("GET /users/12 HTTP/1.1\r\nHost: example.com\r\n\r\n")
+	var err error = Wrap(WithDiagnostic(io.EOF, "request", dump), "load user")
+	err = WithDiagnostic(err, "heap", []byte{0x1f, 0x8b, 0x00})
+
+	diagnostics := FindDiagnostics(err)
+
+	assert.Equal(t, map[string][]byte{
+		"request": dump,
+		"heap":    {0x1f, 0x8b, 0x00},
+	}, diagnostics)
+}
+
+func Test_find_diagnostics_outermost_wins(t *testing.T) {
+	err := WithDiagnostic(WithDiagnostic(io.EOF, "request", []byte("inner")), "request", []byte("outer"))
+
+	assert.Equal(t, []byte("outer"), FindDiagnostics(err)["request"])
+}
+
+func Test_find_diagnostics_empty(t *testing.T) {
+	assert.Empty(t, FindDiagnostics(Wrap(io.EOF, "read")))
+	assert.Empty(t, FindDiagnostics(nil))
+}
+
+func Test_diagnostic_excluded_from_output(t *testing.T) {
+	err := WithDiagnostic(io.EOF, "request", []byte("secret dump"))
+
+	assert.Equal(t, "EOF", err.Error())
+	assert.NotContains(t, fmt.Sprintf("%+v", err), "secret dump")
+	data, marshalErr := MarshalJSON(err)
+	assert.Nil(t, marshalErr)
+	assert.Equal(t, `{"message":"EOF"}`, string(data))
+}
@@ -102,14 +102,35 @@ import (
 // New returns an error with the supplied message and formats
 // according to a format specifier and returns the string
 // as a value that satisfies error.
-// New also records the stack trace at the point it was called.
+// New also records the stack trace at the point it was called, unless
+// SetSkipEmptyStack is enabled and New is called with an empty message and
+// no arguments.
 func New(message string, args ...interface{}) *fundamental {
+	if message == "" && len(args) == 0 && skipEmptyStackEnabled() {
+		return newFundamental(emptyStack, message)
+	}
+	return newFundamental(callers(), message, args...)
+}
+
+// NewLiteral returns an error with the supplied message, like New, but
+// never interprets the message as a format specifier. Use it for messages
+// that come from user input.
+func NewLiteral(message string) *fundamental {
+	if message == "" && skipEmptyStackEnabled() {
+		return newFundamental(emptyStack, message)
+	}
+	return newFundamental(callers(), message)
+}
+
+// newFundamental builds the error for New and the other constructors that
+// capture their own stack.
+func newFundamental(stack *stack, message string, args ...interface{}) *fundamental {
 	if len(args) > 0 {
 		message = fmt.Sprintf(message, args...)
 	}
 	return &fundamental{
 		msg:   message,
-		stack: callers(),
+		stack: stack,
 	}
 }
 
@@ -117,6 +138,10 @@ func New(message string, args ...interface{}) *fundamental {
 type fundamental struct {
 	msg string
 	*stack
+	// pooled is set on errors created by NewPooled.
+	pooled bool
+	// released is 1 while a pooled error is in the pool.
+	released int32
 }
 
 func (f *fundamental) Error() string {
@@ -127,8 +152,10 @@ func (f *fundamental) Format(s fmt.State, verb rune) {
 	switch verb {
 	case 'v':
 		if s.Flag('+') {
-			io.WriteString(s, f.msg)
-			f.stack.Format(s, verb)
+			if formatTrace(s, f) {
+				return
+			}
+			formatWithStack(s, f.stack, f.msg)
 			return
 		}
 		fallthrough
@@ -136,6 +163,8 @@ func (f *fundamental) Format(s fmt.State, verb rune) {
 		io.WriteString(s, f.msg)
 	case 'q':
 		fmt.Fprintf(s, "%q", f.msg)
+	case 'j':
+		formatJSON(s, f)
 	}
 }
 
@@ -144,32 +173,61 @@ func (f *fundamental) StackTrace() StackTrace {
 }
 
 func (f *fundamental) Wrap(message string, args ...interface{}) *withMessage {
-	return WithMessage(f, message, args...)
+	return newMessage(f, message, args...)
 }
 
 func (f *fundamental) Level(level syslog.Level) *withLevel {
-	return WithLevel(f, level)
+	return withLevelAt(f, level, 1)
 }
 
 func (f *fundamental) Status(status int) *withStatus {
 	return WithStatus(f, status)
 }
 
+func (f *fundamental) Alert() *withAlert {
+	return &withAlert{f}
+}
+
+func (f *fundamental) With(key string, value interface{}) *withField {
+	return &withField{cause: f, key: key, value: value}
+}
+
+// FindLevel returns the outermost level in the chain of err. Without an
+// explicit level, the level registered for a matching sentinel with
+// RegisterLevel is used.
 func FindLevel(err error) (syslog.Level, bool) {
 	var level syslog.Level
 	var levelHolder *withLevel
 
 	if !As(err, &levelHolder) {
+		if level, ok := registeredLevelOf(err); ok {
+			return level, true
+		}
 		return level, false
 	}
 
 	return levelHolder.level, true
 }
 
+// WithLevel annotates err with a level. If a threshold is configured with
+// SetStackLevelThreshold and level meets it, a stack trace is recorded when
+// the chain of err has none yet. If err is nil, WithLevel returns nil.
 func WithLevel(err error, level syslog.Level) *withLevel {
+	return withLevelAt(err, level, 1)
+}
+
+// withLevelAt builds the error for WithLevel. A stack trace, if recorded,
+// starts skip frames above the caller of withLevelAt.
+func withLevelAt(err error, level syslog.Level, skip int) *withLevel {
 	if err == nil {
 		return nil
 	}
+	if meetsStackLevelThreshold(level) && !hasStack(err) {
+		err = &withStack{
+			err,
+			callersAt(skip + 1),
+		}
+	}
 	return &withLevel{
 		err,
 		level,
@@ -186,36 +244,75 @@ func (w *withLevel) Error() string {
 }
 
 func (w *withLevel) Format(st fmt.State, verb rune) {
-	Format(st, verb, w.cause)
+	formatCause(st, verb, w, w.cause)
 }
 
 func (w *withLevel) Wrap(message string, args ...interface{}) *withMessage {
-	return WithMessage(w, message, args...)
+	return newMessage(w, message, args...)
 }
 
 func (w *withLevel) Unwrap() error {
 	return w.cause
 }
 
+func (w *withLevel) Cause() error {
+	return Unwrap(w)
+}
+
+// LevelValue returns the level, so the error satisfies LevelValuer.
+func (w *withLevel) LevelValue() syslog.Level { return w.level }
+
 func (w *withLevel) Level(level syslog.Level) *withLevel {
-	return WithLevel(w, level)
+	return withLevelAt(w, level, 1)
 }
 
 func (w *withLevel) Status(status int) *withStatus {
 	return WithStatus(w, status)
 }
 
+func (w *withLevel) Alert() *withAlert {
+	return &withAlert{w}
+}
+
+func (w *withLevel) With(key string, value interface{}) *withField {
+	return &withField{cause: w, key: key, value: value}
+}
+
+// FindStatus returns the outermost status in the chain of err, or the one
+// selected by SetStatusResolution when the chain holds several. Without an
+// explicit status, the status registered for a matching sentinel with
+// RegisterStatus is used. Otherwise it returns 500 and false.
 func FindStatus(err error) (int, bool) {
 	var statusHolder *withStatus
 
 	ok := As(err, &statusHolder)
 	if !ok {
+		if status, ok := registeredStatusOf(err); ok {
+			return status, true
+		}
 		return net.StatusInternalServerError, false
 	}
 
+	if resolution := currentStatusResolution(); resolution != StatusOutermost {
+		return resolveStatus(resolution, FindAllStatuses(err), statusHolder.status), true
+	}
+
 	return statusHolder.status, true
 }
 
+// FindAllStatuses returns every status set in the chain of err, from the
+// outermost to the innermost, in the order in which FindStatus looks for
+// them. It returns an empty slice if there is none.
+func FindAllStatuses(err error) []int {
+	statuses := []int{}
+	walkAsOrder(err, func(err error) {
+		if statusHolder, ok := err.(*withStatus); ok {
+			statuses = append(statuses, statusHolder.status)
+		}
+	})
+	return statuses
+}
+
 func WithStatus(err error, status int) *withStatus {
 	if err == nil {
 		return nil
@@ -236,23 +333,38 @@ func (w *withStatus) Error() string {
 }
 
 func (w *withStatus) Format(st fmt.State, verb rune) {
-	Format(st, verb, w.cause)
+	formatCause(st, verb, w, w.cause)
 }
 
 func (w *withStatus) Wrap(message string, args ...interface{}) *withMessage {
-	return WithMessage(w, message, args...)
+	return newMessage(w, message, args...)
 }
 
 func (w *withStatus) Unwrap() error { return w.cause }
 
+func (w *withStatus) Cause() error {
+	return Unwrap(w)
+}
+
+// StatusCode returns the status, so the error satisfies StatusCoder.
+func (w *withStatus) StatusCode() int { return w.status }
+
 func (w *withStatus) Level(status syslog.Level) *withLevel {
-	return WithLevel(w, status)
+	return withLevelAt(w, status, 1)
 }
 
 func (w *withStatus) Status(status int) *withStatus {
 	return WithStatus(w, status)
 }
 
+func (w *withStatus) Alert() *withAlert {
+	return &withAlert{w}
+}
+
+func (w *withStatus) With(key string, value interface{}) *withField {
+	return &withField{cause: w, key: key, value: value}
+}
+
 // WithStack annotates err with a stack trace at the point WithStack was called.
 // If err is nil, WithStack returns nil.
 func WithStack(err error) error {
@@ -265,14 +377,64 @@ func WithStack(err error) error {
 	}
 }
 
+// FindStack returns the outermost stack trace in the chain of err. If a
+// threshold is configured with SetStackLevelThreshold, errors with a level
+// below the threshold report no stack trace. Empty stack traces, e.g. of
+// errors created while stack capture was disabled with SetStackEnabled, are
+// skipped.
 func FindStack(err error) (StackTrace, bool) {
+	if belowStackLevelThreshold(err) {
+		return StackTrace{}, false
+	}
+	return findStack(err)
+}
+
+// findStack returns the outermost non-empty stack trace in the chain of err,
+// regardless of any threshold.
+func findStack(err error) (StackTrace, bool) {
 	var stackHolder interface{ StackTrace() StackTrace }
 
 	if !As(err, &stackHolder) {
 		return StackTrace{}, false
 	}
+	if stack := stackHolder.StackTrace(); len(stack) > 0 {
+		return stack, true
+	}
+	for _, link := range Links(err) {
+		if stackHolder, ok := link.(StackTracer); ok {
+			if stack := stackHolder.StackTrace(); len(stack) > 0 {
+				return stack, true
+			}
+		}
+	}
+	return StackTrace{}, false
+}
 
-	return stackHolder.StackTrace(), true
+// FindStackWhere returns the outermost stack trace in the chain of err whose
+// top frame satisfies pred. This selects the most relevant trace when the
+// chain holds several of them, e.g. the one captured in a given package.
+func FindStackWhere(err error, pred func(FrameInfo) bool) (StackTrace, bool) {
+	if belowStackLevelThreshold(err) {
+		return StackTrace{}, false
+	}
+	for _, link := range Links(err) {
+		stackHolder, ok := link.(StackTracer)
+		if !ok {
+			continue
+		}
+		stack := stackHolder.StackTrace()
+		if len(stack) > 0 && pred(stack[0].Info()) {
+			return stack, true
+		}
+	}
+	return StackTrace{}, false
+}
+
+// hasStack reports whether the chain of err contains a stack trace,
+// regardless of any threshold.
+func hasStack(err error) bool {
+	_, ok := findStack(err)
+	return ok
 }
 
 type withStack struct {
@@ -284,8 +446,10 @@ func (w *withStack) Format(s fmt.State, verb rune) {
 	switch verb {
 	case 'v':
 		if s.Flag('+') {
-			fmt.Fprintf(s, "%+v", w.Unwrap())
-			w.stack.Format(s, verb)
+			if formatTrace(s, w) {
+				return
+			}
+			formatWithStack(s, w.stack, fmt.Sprintf("%+v", w.Unwrap()))
 			return
 		}
 		fallthrough
@@ -293,27 +457,41 @@ func (w *withStack) Format(s fmt.State, verb rune) {
 		io.WriteString(s, w.Error())
 	case 'q':
 		fmt.Fprintf(s, "%q", w.Error())
+	case 'j':
+		formatJSON(s, w)
 	}
 }
 
 func (w *withStack) Wrap(message string, args ...interface{}) *withMessage {
-	return WithMessage(w, message, args...)
+	return newMessage(w, message, args...)
 }
 
 func (w *withStack) Unwrap() error { return w.error }
 
+func (w *withStack) Cause() error {
+	return Unwrap(w)
+}
+
 func (w *withStack) StackTrace() StackTrace {
 	return w.stack.StackTrace()
 }
 
 func (w *withStack) Level(level syslog.Level) *withLevel {
-	return WithLevel(w, level)
+	return withLevelAt(w, level, 1)
 }
 
 func (w *withStack) Status(status int) *withStatus {
 	return WithStatus(w, status)
 }
 
+func (w *withStack) Alert() *withAlert {
+	return &withAlert{w}
+}
+
+func (w *withStack) With(key string, value interface{}) *withField {
+	return &withField{cause: w, key: key, value: value}
+}
+
 // Wrap returns an error annotating err with a stack trace
 // at the point Wrap is called, and the supplied message.
 // If err is nil, Wrap returns nil.
@@ -321,6 +499,12 @@ func Wrap(err error, message string, args ...interface{}) *withStack {
 	if err == nil {
 		return nil
 	}
+	return newWrap(err, callers(), message, args...)
+}
+
+// newWrap builds the error for Wrap and the other functions that wrap with
+// a message and capture their own stack.
+func newWrap(err error, stack *stack, message string, args ...interface{}) *withStack {
 	if len(args) > 0 {
 		message = fmt.Sprintf(message, args...)
 	}
@@ -328,14 +512,62 @@ func Wrap(err error, message string, args ...interface{}) *withStack {
 		cause: err,
 		msg:   message,
 	}
+	if hooked := applyHook(err); hooked != nil {
+		err = hooked
+	}
 	return &withStack{
 		err,
-		callers(),
+		stack,
 	}
 }
 
-// WithMessage annotates err with a new message.
-func WithMessage(err error, message string, args ...interface{}) *withMessage {
+// WrapMany returns an error annotating err with all messages at once, from
+// the innermost to the outermost, and a single stack trace at the point
+// WrapMany is called. WrapMany(err, "a", "b") reads "b: a: " followed by
+// the message of err. If err is nil, WrapMany returns nil.
+func WrapMany(err error, messages ...string) error {
+	if err == nil {
+		return nil
+	}
+	if len(messages) == 0 {
+		return &withStack{
+			err,
+			callers(),
+		}
+	}
+	last := len(messages) - 1
+	for _, message := range messages[:last] {
+		err = &withMessage{
+			cause: err,
+			msg:   message,
+		}
+	}
+	return newWrap(err, callers(), messages[last])
+}
+
+// WrapShallow returns an error annotating err with the supplied message, like
+// Wrap, but without recording a stack trace. Use it for inner layers of an
+// operation whose stack trace is already recorded, to save the cost of
+// capturing another one. If err is nil, WrapShallow returns nil.
+func WrapShallow(err error, message string, args ...interface{}) *withMessage {
+	if err == nil {
+		return nil
+	}
+	return newMessage(err, message, args...)
+}
+
+// WithMessage annotates err with a new message. Unlike Wrap, WithMessage
+// returns an error with only the message if err is nil, unless strict mode
+// is enabled with SetStrictNilWrap; then it returns nil.
+func WithMessage(err error, message string, args ...interface{}) error {
+	if err == nil && strictNilWrapEnabled() {
+		return nil
+	}
+	return newMessage(err, message, args...)
+}
+
+// newMessage builds the error for WithMessage and the fluent Wrap methods.
+func newMessage(err error, message string, args ...interface{}) *withMessage {
 	if len(args) > 0 {
 		message = fmt.Sprintf(message, args...)
 	}
@@ -352,9 +584,9 @@ type withMessage struct {
 
 func (w *withMessage) Error() string {
 	if w.cause == nil || w.cause.Error() == "" {
-		return w.msg
+		return truncateMessage(w.msg)
 	}
-	return w.msg + ": " + w.cause.Error()
+	return truncateMessage(w.msg + ": " + w.cause.Error())
 }
 
 func (w *withMessage) Format(s fmt.State, verb rune) {
@@ -368,25 +600,39 @@ func (w *withMessage) Format(s fmt.State, verb rune) {
 		fallthrough
 	case 's', 'q':
 		io.WriteString(s, w.Error())
+	case 'j':
+		formatJSON(s, w)
 	}
 }
 
 func (w *withMessage) Wrap(message string, args ...interface{}) *withMessage {
-	return WithMessage(w, message, args...)
+	return newMessage(w, message, args...)
 }
 
 func (w *withMessage) Unwrap() error {
 	return w.cause
 }
 
+func (w *withMessage) Cause() error {
+	return Unwrap(w)
+}
+
 func (w *withMessage) Level(level syslog.Level) *withLevel {
-	return WithLevel(w, level)
+	return withLevelAt(w, level, 1)
 }
 
 func (w *withMessage) Status(status int) *withStatus {
 	return WithStatus(w, status)
 }
 
+func (w *withMessage) Alert() *withAlert {
+	return &withAlert{w}
+}
+
+func (w *withMessage) With(key string, value interface{}) *withField {
+	return &withField{cause: w, key: key, value: value}
+}
+
 // Unwrap returns the underlying cause of the error, if possible.
 // An error value has a cause if it implements the following
 // interface:
@@ -398,6 +644,9 @@ func (w *withMessage) Status(status int) *withStatus {
 // If the error does not implement Unwrap, the original error will
 // be returned. If the error is nil, nil will be returned without further
 // investigation.
+//
+// The wrappers of this package also have a Cause method that returns the
+// same, so Cause from github.com/pkg/errors keeps working on their errors.
 func Unwrap(err error) error {
 	for err != nil {
 		unwrapper, ok := err.(unwrapper)
@@ -417,6 +666,16 @@ type StackTracer interface {
 	StackTrace() StackTrace
 }
 
+// LevelValuer is implemented by errors that carry a log level. Errors
+// created by WithLevel implement it, which lets code that does not import
+// this package read the level with a type assertion or errors.As on an
+// interface of its own, just like the status through StatusCoder.
+type LevelValuer interface {
+	LevelValue() syslog.Level
+}
+
+// Format formats err according to the fmt.Formatter interface. It uses the
+// Format method of err if present, and prints its message otherwise.
 func Format(st fmt.State, verb rune, err error) {
 	if cause, ok := err.(interface{ Format(fmt.State, rune) }); ok {
 		cause.Format(st, verb)
@@ -424,3 +683,14 @@ func Format(st fmt.State, verb rune, err error) {
 		io.WriteString(st, err.Error())
 	}
 }
+
+// formatCause formats a layer w that adds no text of its own by formatting
+// its cause. The 'j' verb is handled by w itself, so the JSON contains the
+// metadata of w.
+func formatCause(st fmt.State, verb rune, w error, cause error) {
+	if verb == 'j' {
+		formatJSON(st, w)
+		return
+	}
+	Format(st, verb, cause)
+}
@@ -257,6 +257,28 @@ func Test_status_fluent_status(t *testing.T) {
 	assert.Equal(t, net.StatusBadRequest, level)
 }
 
+func Test_all_statuses_without_status(t *testing.T) {
+	assert.Equal(t, []int{}, FindAllStatuses(New("database error")))
+}
+
+func Test_all_statuses_outer_to_inner(t *testing.T) {
+	err := New("not found").Status(net.StatusNotFound).Wrap("database error").Status(net.StatusInternalServerError)
+
+	assert.Equal(t, []int{net.StatusInternalServerError, net.StatusNotFound}, FindAllStatuses(err))
+
+	status, _ := FindStatus(err)
+	assert.Equal(t, net.StatusInternalServerError, status)
+}
+
+func Test_all_statuses_through_wrap_cause(t *testing.T) {
+	err := WrapCause(NotFound("user not found"), WithStatus(io.EOF, net.StatusBadGateway))
+
+	assert.Equal(t, []int{net.StatusNotFound, net.StatusBadGateway}, FindAllStatuses(err))
+
+	status, _ := FindStatus(err)
+	assert.Equal(t, net.StatusNotFound, status)
+}
+
 type nilError struct{}
 
 func (nilError) Error() string { return "nil error" }
@@ -304,6 +326,29 @@ func Test_errorf(t *testing.T) {
 	}
 }
 
+func Test_wrap_shallow_nil(t *testing.T) {
+	assert.Nil(t, WrapShallow(nil, "no error"))
+}
+
+func Test_wrap_shallow(t *testing.T) {
+	inner := New("not found")
+	err := WrapShallow(WrapShallow(inner, "load user %d", 12), "handle request")
+
+	assert.Equal(t, "handle request: load user 12: not found", err.Error())
+
+	stack, ok := FindStack(err)
+	assert.True(t, ok)
+	assert.Equal(t, inner.StackTrace(), stack)
+
+	stacks := 0
+	for _, link := range Links(err) {
+		if _, ok := link.(StackTracer); ok {
+			stacks++
+		}
+	}
+	assert.Equal(t, 1, stacks)
+}
+
 func Test_with_stack_nil(t *testing.T) {
 	got := WithStack(nil)
 	if got != nil {
@@ -329,7 +374,7 @@ func Test_with_stack(t *testing.T) {
 }
 
 func Test_with_message_nil(t *testing.T) {
-	got := WithMessage(nil, "no error").Unwrap()
+	got := stderrors.Unwrap(WithMessage(nil, "no error"))
 	if got != nil {
 		t.Errorf("WithMessage(nil, \"no error\"): got %#v, expected nil", got)
 	}
@@ -355,8 +400,8 @@ func Test_with_message(t *testing.T) {
 
 func Test_with_messagef_nil(t *testing.T) {
 	got := WithMessage(nil, "no error")
-	if got.Unwrap() != nil {
-		t.Errorf("WithMessage(nil, \"no error\"): got %#v, expected nil", got.Unwrap())
+	if stderrors.Unwrap(got) != nil {
+		t.Errorf("WithMessage(nil, \"no error\"): got %#v, expected nil", stderrors.Unwrap(got))
 	}
 	assert.Equal(t, "no error", got.Error())
 }
@@ -405,3 +450,57 @@ func Test_error_equality(t *testing.T) {
 		}
 	}
 }
+
+func Test_accessor_interfaces(t *testing.T) {
+	var _ StatusCoder = WithStatus(io.EOF, 404)
+	var _ LevelValuer = WithLevel(io.EOF, log_level.WARNING)
+
+	// interfaces as foreign code would declare them
+	type statusCoder interface{ StatusCode() int }
+	type levelValuer interface{ LevelValue() log_level.Level }
+
+	err := Wrap(NotFound("user not found").Level(log_level.WARNING), "load user")
+
+	var coder statusCoder
+	assert.True(t, stderrors.As(err, &coder))
+	assert.Equal(t, 404, coder.StatusCode())
+	var valuer levelValuer
+	assert.True(t, stderrors.As(err, &valuer))
+	assert.Equal(t, log_level.WARNING, valuer.LevelValue())
+}
+
+func Test_wrap_many_nil(t *testing.T) {
+	assert.Nil(t, WrapMany(nil, "a", "b"))
+}
+
+func Test_wrap_many_order(t *testing.T) {
+	err := WrapMany(io.EOF, "a", "b", "c")
+
+	assert.EqualError(t, err, "c: b: a: EOF")
+	assert.True(t, Is(err, io.EOF))
+}
+
+func Test_wrap_many_single_stack(t *testing.T) {
+	err := WrapMany(io.EOF, "a", "b %d")
+
+	assert.EqualError(t, err, "b %d: a: EOF")
+	var stacks int
+	for _, link := range Links(err) {
+		if _, ok := link.(StackTracer); ok {
+			stacks++
+		}
+	}
+	assert.Equal(t, 1, stacks)
+	stack, ok := FindStack(err)
+	assert.True(t, ok)
+	assert.Equal(t, "Test_wrap_many_single_stack", funcname(stack[0].name()))
+}
+
+func Test_wrap_many_without_messages(t *testing.T) {
+	err := WrapMany(io.EOF)
+
+	assert.EqualError(t, err, "EOF")
+	stack, ok := FindStack(err)
+	assert.True(t, ok)
+	assert.Equal(t, "Test_wrap_many_without_messages", funcname(stack[0].name()))
+}
@@ -0,0 +1,17 @@
+// Package errorstest provides test helpers for code that returns errors of
+// github.com/confetti-framework/errors.
+package errorstest
+
+import (
+	"testing"
+)
+
+// NoError fails the test immediately if err is not nil. The failure shows
+// err formatted with %+v, including its stack traces, which tells more
+// about the origin of the error than its message alone.
+func NoError(t testing.TB, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+}
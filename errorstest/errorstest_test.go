@@ -0,0 +1,42 @@
+package errorstest
+
+import (
+	"fmt"
+	"github.com/confetti-framework/errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type fakeT struct {
+	testing.TB
+	helper bool
+	failed string
+}
+
+func (f *fakeT) Helper() {
+	f.helper = true
+}
+
+func (f *fakeT) Fatalf(format string, args ...interface{}) {
+	f.failed = fmt.Sprintf(format, args...)
+}
+
+func Test_no_error_passes(t *testing.T) {
+	fake := &fakeT{}
+
+	NoError(fake, nil)
+
+	assert.Equal(t, "", fake.failed)
+}
+
+func Test_no_error_fails_with_stack(t *testing.T) {
+	fake := &fakeT{}
+
+	NoError(fake, errors.Wrap(errors.New("row not found"), "load user"))
+
+	assert.True(t, fake.helper)
+	assert.Contains(t, fake.failed, "unexpected error: row not found\n")
+	assert.Contains(t, fake.failed, "load user\n")
+	assert.Contains(t, fake.failed, "errorstest.Test_no_error_fails_with_stack\n")
+	assert.Regexp(t, `\t.+/errorstest/errorstest_test\.go:\d+`, fake.failed)
+}
@@ -141,7 +141,7 @@ func ExampleWrap_extended() {
 	//      /home/dfc/src/github.com/confetti-framework/errors/example_test.go:50: outer
 }
 
-func ExampleWrapFormat() {
+func ExampleWrap_format() {
 	cause := errors.New("whoops")
 	err := errors.Wrap(cause, "oh noes #%d", 2)
 	fmt.Println(err)
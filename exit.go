@@ -0,0 +1,63 @@
+package errors
+
+import (
+	syslog "github.com/confetti-framework/syslog/log_level"
+	"sync"
+)
+
+var (
+	exitCodesMu sync.RWMutex
+	exitCodes   = defaultExitCodes()
+)
+
+func defaultExitCodes() map[syslog.Level]int {
+	return map[syslog.Level]int{
+		syslog.EMERGENCY: 2,
+		syslog.ERROR:     1,
+	}
+}
+
+// SetLevelExitCodes sets the exit codes LevelToExitCode maps levels to. A
+// level gets the code of the least severe configured level it is at least
+// as severe as, and 0 if it is less severe than every configured level.
+// The default maps EMERGENCY to 2, ALERT up to ERROR to 1 and the other
+// levels to 0. A nil map restores the default.
+func SetLevelExitCodes(codes map[syslog.Level]int) {
+	copied := defaultExitCodes()
+	if codes != nil {
+		copied = make(map[syslog.Level]int, len(codes))
+		for level, code := range codes {
+			copied[level] = code
+		}
+	}
+	exitCodesMu.Lock()
+	defer exitCodesMu.Unlock()
+	exitCodes = copied
+}
+
+// LevelToExitCode returns the exit code for level, as configured with
+// SetLevelExitCodes.
+func LevelToExitCode(level syslog.Level) int {
+	exitCodesMu.RLock()
+	defer exitCodesMu.RUnlock()
+	code, match, found := 0, syslog.Level(0), false
+	for configured, configuredCode := range exitCodes {
+		if level <= configured && (!found || configured < match) {
+			code, match, found = configuredCode, configured, true
+		}
+	}
+	return code
+}
+
+// ExitCode returns the exit code for the level of err, or DefaultLogLevel
+// without one, so a command line tool can end with
+//
+//	os.Exit(errors.ExitCode(errors.MostSevere(errs...)))
+//
+// ExitCode returns 0 if err is nil.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	return LevelToExitCode(levelOrDefault(err))
+}
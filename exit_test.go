@@ -0,0 +1,53 @@
+package errors
+
+import (
+	"github.com/confetti-framework/syslog/log_level"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func Test_level_to_exit_code_default(t *testing.T) {
+	tests := map[log_level.Level]int{
+		log_level.EMERGENCY: 2,
+		log_level.ALERT:     1,
+		log_level.CRITICAL:  1,
+		log_level.ERROR:     1,
+		log_level.WARNING:   0,
+		log_level.NOTICE:    0,
+		log_level.INFO:      0,
+		log_level.DEBUG:     0,
+	}
+	for level, code := range tests {
+		assert.Equal(t, code, LevelToExitCode(level), "level %d", level)
+	}
+}
+
+func Test_set_level_exit_codes(t *testing.T) {
+	SetLevelExitCodes(map[log_level.Level]int{log_level.WARNING: 3})
+	defer SetLevelExitCodes(nil)
+
+	assert.Equal(t, 3, LevelToExitCode(log_level.EMERGENCY))
+	assert.Equal(t, 3, LevelToExitCode(log_level.WARNING))
+	assert.Equal(t, 0, LevelToExitCode(log_level.NOTICE))
+}
+
+func Test_set_level_exit_codes_nil_restores_default(t *testing.T) {
+	SetLevelExitCodes(map[log_level.Level]int{})
+	assert.Equal(t, 0, LevelToExitCode(log_level.EMERGENCY))
+
+	SetLevelExitCodes(nil)
+	assert.Equal(t, 2, LevelToExitCode(log_level.EMERGENCY))
+}
+
+func Test_exit_code(t *testing.T) {
+	errs := []error{
+		WithLevel(io.EOF, log_level.WARNING),
+		WithLevel(io.EOF, log_level.EMERGENCY),
+	}
+
+	assert.Equal(t, 2, ExitCode(MostSevere(errs...)))
+	assert.Equal(t, 1, ExitCode(io.EOF))
+	assert.Equal(t, 0, ExitCode(WithLevel(io.EOF, log_level.INFO)))
+	assert.Equal(t, 0, ExitCode(nil))
+}
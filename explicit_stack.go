@@ -0,0 +1,108 @@
+package errors
+
+import (
+	"fmt"
+	syslog "github.com/confetti-framework/syslog/log_level"
+	"io"
+)
+
+// WithExplicitStack annotates err with a stack trace that was recorded
+// elsewhere, e.g. in another process or by a recovered panic, instead of
+// capturing the current one. The frames are printed by %+v in the same
+// layout as captured stack traces. Since they have no program counters,
+// they are not reported by FindStack; use FindFrames instead.
+// If err is nil, WithExplicitStack returns nil.
+func WithExplicitStack(err error, frames []FrameInfo) error {
+	if err == nil {
+		return nil
+	}
+	return &withExplicitStack{
+		err,
+		append([]FrameInfo(nil), frames...),
+	}
+}
+
+// FindFrames returns the symbolized frames of the outermost stack trace in
+// the chain of err, whether it was captured or supplied with
+// WithExplicitStack.
+func FindFrames(err error) ([]FrameInfo, bool) {
+	if belowStackLevelThreshold(err) {
+		return nil, false
+	}
+	for _, link := range Links(err) {
+		switch stackHolder := link.(type) {
+		case *withExplicitStack:
+			return stackHolder.StackFrames(), true
+		case StackTracer:
+			if stack := stackHolder.StackTrace(); len(stack) > 0 {
+				frames := make([]FrameInfo, len(stack))
+				for i, frame := range stack {
+					frames[i] = frame.Info()
+				}
+				return frames, true
+			}
+		}
+	}
+	return nil, false
+}
+
+type withExplicitStack struct {
+	error
+	frames []FrameInfo
+}
+
+// StackFrames returns a copy of the supplied frames.
+func (w *withExplicitStack) StackFrames() []FrameInfo {
+	return append([]FrameInfo(nil), w.frames...)
+}
+
+func (w *withExplicitStack) Format(s fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if s.Flag('+') {
+			fmt.Fprintf(s, "%+v", w.Unwrap())
+			formatter := currentFrameFormatter()
+			for _, frame := range w.frames {
+				if formatter != nil {
+					io.WriteString(s, "\n"+formatter(frame))
+					continue
+				}
+				fmt.Fprintf(s, "\n%s\n\t%s:%d", frame.Function, frame.File, frame.Line)
+			}
+			return
+		}
+		fallthrough
+	case 's':
+		io.WriteString(s, w.Error())
+	case 'q':
+		fmt.Fprintf(s, "%q", w.Error())
+	case 'j':
+		formatJSON(s, w)
+	}
+}
+
+func (w *withExplicitStack) Unwrap() error { return w.error }
+
+func (w *withExplicitStack) Cause() error {
+	return Unwrap(w)
+}
+
+func (w *withExplicitStack) Wrap(message string, args ...interface{}) *withMessage {
+	return newMessage(w, message, args...)
+}
+
+func (w *withExplicitStack) Level(level syslog.Level) *withLevel {
+	return withLevelAt(w, level, 1)
+}
+
+func (w *withExplicitStack) Status(status int) *withStatus {
+	return WithStatus(w, status)
+}
+
+func (w *withExplicitStack) Alert() *withAlert {
+	return &withAlert{w}
+}
+
+func (w *withExplicitStack) With(key string, value interface{}) *withField {
+	return &withField{cause: w, key: key, value: value}
+}
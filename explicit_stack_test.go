@@ -0,0 +1,67 @@
+package errors
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"regexp"
+	"testing"
+)
+
+var explicitFrames = []FrameInfo{
+	{"github.com/acme/billing.(*Invoice).Send", "/src/billing/invoice.go", 42},
+	{"main.main", "/src/main.go", 7},
+}
+
+func Test_explicit_stack_with_nil(t *testing.T) {
+	assert.Nil(t, WithExplicitStack(nil, explicitFrames))
+}
+
+func Test_explicit_stack_format(t *testing.T) {
+	err := WithExplicitStack(io.EOF, explicitFrames)
+
+	assert.Equal(t, "EOF", fmt.Sprintf("%s", err))
+	assert.Equal(t, "EOF\n"+
+		"github.com/acme/billing.(*Invoice).Send\n"+
+		"\t/src/billing/invoice.go:42\n"+
+		"main.main\n"+
+		"\t/src/main.go:7", fmt.Sprintf("%+v", err))
+}
+
+func Test_explicit_stack_format_matches_captured_layout(t *testing.T) {
+	frame := caller()
+	err := WithExplicitStack(io.EOF, []FrameInfo{frame.Info()})
+
+	assert.Equal(t, "EOF\n"+fmt.Sprintf("%+v", frame), fmt.Sprintf("%+v", err))
+}
+
+func Test_explicit_stack_frames(t *testing.T) {
+	err := Wrap(WithExplicitStack(io.EOF, explicitFrames), "remote call failed")
+
+	frames, ok := FindFrames(Unwrap(err.Unwrap()))
+	assert.False(t, ok)
+	assert.Nil(t, frames)
+
+	frames, ok = FindFrames(err.Unwrap())
+	assert.True(t, ok)
+	assert.Equal(t, explicitFrames, frames)
+}
+
+func Test_explicit_stack_frames_are_copied(t *testing.T) {
+	frames := append([]FrameInfo(nil), explicitFrames...)
+	err := WithExplicitStack(io.EOF, frames)
+	frames[0].Line = 1
+
+	found, _ := FindFrames(err)
+	found[1].Line = 1
+
+	found, _ = FindFrames(err)
+	assert.Equal(t, explicitFrames, found)
+}
+
+func Test_find_frames_of_captured_stack(t *testing.T) {
+	frames, ok := FindFrames(Wrap(io.EOF, "read failed"))
+
+	assert.True(t, ok)
+	assert.Regexp(t, regexp.MustCompile(`\.Test_find_frames_of_captured_stack$`), frames[0].Function)
+}
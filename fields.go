@@ -0,0 +1,123 @@
+package errors
+
+import (
+	"fmt"
+	syslog "github.com/confetti-framework/syslog/log_level"
+)
+
+// WithField annotates err with a key/value pair. If err is nil, WithField
+// returns nil.
+func WithField(err error, key string, value interface{}) error {
+	if err == nil {
+		return nil
+	}
+	return &withField{
+		cause: err,
+		key:   key,
+		value: value,
+	}
+}
+
+// FindFields returns all fields in the chain of err, including the messages
+// per field of NewValidation. When a key is set on multiple layers, the
+// outermost value wins. FindFields returns an empty map
+// if no field is present.
+func FindFields(err error) map[string]interface{} {
+	fields := map[string]interface{}{}
+	for _, link := range Links(err) {
+		switch layer := link.(type) {
+		case *withField:
+			if _, exists := fields[layer.key]; !exists {
+				fields[layer.key] = layer.value
+			}
+		case *withValidation:
+			for key, message := range layer.fields {
+				if _, exists := fields[key]; !exists {
+					fields[key] = message
+				}
+			}
+		}
+	}
+	return fields
+}
+
+// HasField reports whether a field with key is set in the chain of err.
+func HasField(err error, key string) bool {
+	_, ok := findField(err, key)
+	return ok
+}
+
+// FieldString returns the outermost value of the field with key, if it is
+// a string.
+func FieldString(err error, key string) (string, bool) {
+	value, _ := findField(err, key)
+	s, ok := value.(string)
+	return s, ok
+}
+
+// FieldInt returns the outermost value of the field with key, if it is an
+// int.
+func FieldInt(err error, key string) (int, bool) {
+	value, _ := findField(err, key)
+	i, ok := value.(int)
+	return i, ok
+}
+
+// findField returns the outermost value of the field with key.
+func findField(err error, key string) (interface{}, bool) {
+	for _, link := range Links(err) {
+		switch layer := link.(type) {
+		case *withField:
+			if layer.key == key {
+				return layer.value, true
+			}
+		case *withValidation:
+			if message, ok := layer.fields[key]; ok {
+				return message, true
+			}
+		}
+	}
+	return nil, false
+}
+
+type withField struct {
+	cause error
+	key   string
+	value interface{}
+}
+
+func (w *withField) Error() string {
+	return w.cause.Error()
+}
+
+func (w *withField) Format(st fmt.State, verb rune) {
+	formatCause(st, verb, w, w.cause)
+}
+
+func (w *withField) Wrap(message string, args ...interface{}) *withMessage {
+	return newMessage(w, message, args...)
+}
+
+func (w *withField) Unwrap() error {
+	return w.cause
+}
+
+func (w *withField) Cause() error {
+	return Unwrap(w)
+}
+
+func (w *withField) Level(level syslog.Level) *withLevel {
+	return withLevelAt(w, level, 1)
+}
+
+func (w *withField) Status(status int) *withStatus {
+	return WithStatus(w, status)
+}
+
+func (w *withField) Alert() *withAlert {
+	return &withAlert{w}
+}
+
+func (w *withField) With(key string, value interface{}) *withField {
+	return &withField{cause: w, key: key, value: value}
+}
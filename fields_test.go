@@ -0,0 +1,89 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func Test_field_with_nil(t *testing.T) {
+	assert.Nil(t, WithField(nil, "id", 1))
+}
+
+func Test_fields_without_fields(t *testing.T) {
+	assert.Empty(t, FindFields(New("not found")))
+	assert.Empty(t, FindFields(nil))
+}
+
+func Test_fields_from_unwrap(t *testing.T) {
+	err := WithField(New("user not found"), "user_id", 12)
+	err = WithField(Wrap(err, "database error"), "table", "users")
+
+	assert.Equal(t, map[string]interface{}{"user_id": 12, "table": "users"}, FindFields(err))
+	assert.Equal(t, "database error: user not found", err.Error())
+}
+
+func Test_fields_outer_wins(t *testing.T) {
+	err := WithField(WithField(io.EOF, "attempt", 1), "attempt", 2)
+
+	assert.Equal(t, 2, FindFields(err)["attempt"])
+}
+
+func Test_fields_added_by_hook(t *testing.T) {
+	SetErrorHook(func(err error) error { return WithField(err, "service", "billing") })
+	defer SetErrorHook(nil)
+
+	err := Wrap(io.EOF, "invoice not found")
+
+	assert.Equal(t, "billing", FindFields(err)["service"])
+	assert.Equal(t, "billing", FindFields(Wrap(err, "request failed"))["service"])
+}
+
+func Test_has_field(t *testing.T) {
+	err := Wrap(WithField(io.EOF, "user_id", nil), "database error")
+
+	assert.True(t, HasField(err, "user_id"))
+	assert.False(t, HasField(err, "account"))
+	assert.False(t, HasField(nil, "user_id"))
+}
+
+func Test_field_string(t *testing.T) {
+	err := WithField(WithField(io.EOF, "account", "acme"), "user_id", 12)
+
+	value, ok := FieldString(err, "account")
+	assert.True(t, ok)
+	assert.Equal(t, "acme", value)
+
+	value, ok = FieldString(err, "user_id")
+	assert.False(t, ok)
+	assert.Equal(t, "", value)
+
+	_, ok = FieldString(err, "missing")
+	assert.False(t, ok)
+}
+
+func Test_field_int(t *testing.T) {
+	err := WithField(WithField(io.EOF, "account", "acme"), "user_id", 12)
+
+	value, ok := FieldInt(err, "user_id")
+	assert.True(t, ok)
+	assert.Equal(t, 12, value)
+
+	value, ok = FieldInt(err, "account")
+	assert.False(t, ok)
+	assert.Equal(t, 0, value)
+
+	_, ok = FieldInt(err, "missing")
+	assert.False(t, ok)
+}
+
+func Test_field_getters_use_outermost_value(t *testing.T) {
+	err := WithField(WithField(io.EOF, "attempt", 1), "attempt", "last")
+
+	_, ok := FieldInt(err, "attempt")
+	assert.False(t, ok)
+
+	value, ok := FieldString(err, "attempt")
+	assert.True(t, ok)
+	assert.Equal(t, "last", value)
+}
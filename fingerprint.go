@@ -0,0 +1,51 @@
+package errors
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+)
+
+// Fingerprint returns a short identifier for the place err comes from, so
+// occurrences of the same error can be counted together. It is derived from
+// the type of the root cause and the top frame of the innermost stack trace,
+// and therefore does not change with the values formatted into messages.
+// Without a stack trace the message of the root cause is used instead, or
+// the message of err if its chain ends in a nil cause.
+func Fingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+	root := Unwrap(err)
+	site := err.Error()
+	if root != nil {
+		site = root.Error()
+	}
+	links := Links(err)
+	for i := len(links) - 1; i >= 0; i-- {
+		if stackHolder, ok := links[i].(StackTracer); ok {
+			if stack := stackHolder.StackTrace(); len(stack) > 0 {
+				info := stack[0].Info()
+				site = info.Function + ":" + strconv.Itoa(info.Line)
+				break
+			}
+		}
+	}
+	hash := fnv.New64a()
+	fmt.Fprintf(hash, "%T\n%s", root, site)
+	return strconv.FormatUint(hash.Sum64(), 16)
+}
+
+// Group buckets errs by their Fingerprint, e.g. for a dashboard that shows
+// how often each error occurred. Nil errors are skipped.
+func Group(errs []error) map[string][]error {
+	groups := map[string][]error{}
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		fingerprint := Fingerprint(err)
+		groups[fingerprint] = append(groups[fingerprint], err)
+	}
+	return groups
+}
@@ -0,0 +1,62 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func userNotFound(id int) error {
+	return New("user %d not found", id)
+}
+
+func orderNotFound(id int) error {
+	return New("order %d not found", id)
+}
+
+func Test_fingerprint_nil(t *testing.T) {
+	assert.Equal(t, "", Fingerprint(nil))
+}
+
+func Test_fingerprint_same_site(t *testing.T) {
+	assert.Equal(t, Fingerprint(userNotFound(1)), Fingerprint(userNotFound(2)))
+	assert.Equal(t, Fingerprint(userNotFound(1)), Fingerprint(Wrap(userNotFound(2), "handle request")))
+}
+
+func Test_fingerprint_different_site(t *testing.T) {
+	assert.NotEqual(t, Fingerprint(userNotFound(1)), Fingerprint(orderNotFound(1)))
+}
+
+func Test_fingerprint_without_stack(t *testing.T) {
+	assert.Equal(t, Fingerprint(io.EOF), Fingerprint(WithMessage(io.EOF, "read")))
+	assert.NotEqual(t, Fingerprint(io.EOF), Fingerprint(io.ErrUnexpectedEOF))
+}
+
+func Test_fingerprint_nil_root(t *testing.T) {
+	fingerprint := Fingerprint(WithMessage(nil, "x"))
+
+	assert.NotEmpty(t, fingerprint)
+	assert.Equal(t, fingerprint, Fingerprint(WithMessage(nil, "x")))
+	assert.NotEqual(t, fingerprint, Fingerprint(WithMessage(nil, "y")))
+	assert.Len(t, Group([]error{WithMessage(nil, "x")}), 1)
+}
+
+func Test_group(t *testing.T) {
+	errs := []error{
+		userNotFound(1),
+		orderNotFound(1),
+		nil,
+		userNotFound(2),
+		Wrap(userNotFound(3), "handle request"),
+	}
+
+	groups := Group(errs)
+	assert.Len(t, groups, 2)
+	assert.Len(t, groups[Fingerprint(errs[0])], 3)
+	assert.Equal(t, []error{errs[1]}, groups[Fingerprint(errs[1])])
+}
+
+func Test_group_empty(t *testing.T) {
+	assert.Empty(t, Group(nil))
+	assert.Empty(t, Group([]error{nil}))
+}
@@ -0,0 +1,46 @@
+package errors
+
+import (
+	syslog "github.com/confetti-framework/syslog/log_level"
+)
+
+// fluent is the method set shared by all errors and wrappers of this
+// package, so they can be annotated further by chaining, in any order:
+//
+//	New("user not found").Status(404).With("user_id", 12).Level(log_level.ERROR)
+type fluent interface {
+	error
+	Wrap(message string, args ...interface{}) *withMessage
+	Level(level syslog.Level) *withLevel
+	Status(status int) *withStatus
+	Alert() *withAlert
+	With(key string, value interface{}) *withField
+}
+
+var (
+	_ fluent = (*fundamental)(nil)
+	_ fluent = (*withMessage)(nil)
+	_ fluent = (*withStack)(nil)
+	_ fluent = (*withLevel)(nil)
+	_ fluent = (*withStatus)(nil)
+	_ fluent = (*withAlert)(nil)
+	_ fluent = (*withField)(nil)
+	_ fluent = (*withBecause)(nil)
+	_ fluent = (*withCategory)(nil)
+	_ fluent = (*withCause)(nil)
+	_ fluent = (*withCode)(nil)
+	_ fluent = (*withDiagnostic)(nil)
+	_ fluent = (*withExplicitStack)(nil)
+	_ fluent = (*withHandoff)(nil)
+	_ fluent = (*withHeaders)(nil)
+	_ fluent = (*withHint)(nil)
+	_ fluent = (*withLazyMessage)(nil)
+	_ fluent = (*withLogged)(nil)
+	_ fluent = (*withNumericCode)(nil)
+	_ fluent = (*withOp)(nil)
+	_ fluent = (*withPublicMessage)(nil)
+	_ fluent = (*withRetryAfter)(nil)
+	_ fluent = (*withRetryable)(nil)
+	_ fluent = (*withTime)(nil)
+	_ fluent = (*withValidation)(nil)
+)
@@ -0,0 +1,105 @@
+package errors
+
+import (
+	"github.com/confetti-framework/syslog/log_level"
+	"github.com/stretchr/testify/assert"
+	"io"
+	net "net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func assertAllMetadata(t *testing.T, err error) {
+	status, ok := FindStatus(err)
+	assert.True(t, ok)
+	assert.Equal(t, net.StatusNotFound, status)
+
+	level, ok := FindLevel(err)
+	assert.True(t, ok)
+	assert.Equal(t, log_level.ERROR, level)
+
+	id, ok := FieldInt(err, "id")
+	assert.True(t, ok)
+	assert.Equal(t, 1, id)
+
+	assert.True(t, ShouldAlert(err))
+	assert.Equal(t, "load user: x", err.Error())
+}
+
+func Test_fluent_chaining_in_any_order(t *testing.T) {
+	errs := []error{
+		New("x").Status(net.StatusNotFound).With("id", 1).Level(log_level.ERROR).Alert().Wrap("load user"),
+		New("x").With("id", 1).Level(log_level.ERROR).Alert().Wrap("load user").Status(net.StatusNotFound),
+		New("x").Level(log_level.ERROR).Alert().Wrap("load user").Status(net.StatusNotFound).With("id", 1),
+		New("x").Alert().Wrap("load user").Status(net.StatusNotFound).With("id", 1).Level(log_level.ERROR),
+		New("x").Wrap("load user").Status(net.StatusNotFound).With("id", 1).Level(log_level.ERROR).Alert(),
+		Wrap(New("x"), "load user").With("id", 1).Alert().Level(log_level.ERROR).Status(net.StatusNotFound),
+		New("x").Because(nil).Wrap("load user").Level(log_level.ERROR).With("id", 1).Status(net.StatusNotFound).Alert(),
+	}
+	for _, err := range errs {
+		assertAllMetadata(t, err)
+	}
+}
+
+func Test_fluent_with_on_because(t *testing.T) {
+	err := New("x").Because(io.EOF).With("id", 1)
+
+	id, ok := FieldInt(err, "id")
+	assert.True(t, ok)
+	assert.Equal(t, 1, id)
+	assert.Equal(t, "x: EOF", err.Error())
+}
+
+func Test_fluent_with_keeps_outermost_value(t *testing.T) {
+	err := New("x").With("id", 1).Wrap("load user").With("id", 2)
+
+	assert.Equal(t, 2, FindFields(err)["id"])
+}
+
+func Test_fluent_on_every_wrapper(t *testing.T) {
+	x := New("x")
+	errs := []error{
+		WithCategory(x, "database"),
+		WrapCause(x, io.EOF),
+		WithCode(x, "user_not_found"),
+		WithDiagnostic(x, "query", nil),
+		WithExplicitStack(x, nil),
+		Handoff(x),
+		WithHeaders(x, net.Header{"Retry-After": {"1"}}),
+		WithHint(x, "try again"),
+		WrapLazy(x, func() string { return "lazy" }),
+		WithLogged(x),
+		WithNumericCode(x, 1001),
+		WithOp(x, "LoadUser"),
+		WithPublicMessage(x, "not found"),
+		WithRetryAfter(x, time.Second),
+		WithRetryable(x),
+		WithTime(x),
+		NewValidation(map[string]string{"name": "is required"}),
+	}
+	for _, err := range errs {
+		var wrapper fluent
+		for _, link := range Links(err) {
+			switch link.(type) {
+			case *withStack, *withStatus:
+				continue
+			}
+			wrapper, _ = link.(fluent)
+			break
+		}
+		if !assert.NotNil(t, wrapper, "%T", err) {
+			continue
+		}
+		err = wrapper.Status(net.StatusNotFound).With("id", 1).Level(log_level.ERROR).Alert().Wrap("load user")
+
+		status, _ := FindStatus(err)
+		assert.Equal(t, net.StatusNotFound, status)
+		level, _ := FindLevel(err)
+		assert.Equal(t, log_level.ERROR, level)
+		id, _ := FieldInt(err, "id")
+		assert.Equal(t, 1, id)
+		assert.True(t, ShouldAlert(err))
+		assert.True(t, strings.HasPrefix(err.Error(), "load user: "))
+	}
+}
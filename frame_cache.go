@@ -0,0 +1,57 @@
+package errors
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	frameCacheOn int32
+	// frameCache maps a Frame to its FrameInfo.
+	frameCache sync.Map
+)
+
+// SetFrameCache controls whether frames are symbolized only once. With the
+// cache enabled, the function, file and line of every program counter are
+// looked up on first use and kept, so formatting the same error, or errors
+// from the same call sites, again with %+v is cheaper. The cache is safe for
+// use from multiple goroutines and grows with the number of distinct
+// program counters formatted. Disabling it drops all cached frames. It is
+// disabled by default.
+func SetFrameCache(enabled bool) {
+	var on int32
+	if enabled {
+		on = 1
+	}
+	atomic.StoreInt32(&frameCacheOn, on)
+	if !enabled {
+		frameCache.Range(func(key, _ interface{}) bool {
+			frameCache.Delete(key)
+			return true
+		})
+	}
+}
+
+// cachedFrameInfo returns the symbolized frame from the cache, symbolizing
+// it first if needed. It reports false if the cache is disabled.
+func cachedFrameInfo(f Frame) (FrameInfo, bool) {
+	if atomic.LoadInt32(&frameCacheOn) == 0 {
+		return FrameInfo{}, false
+	}
+	if info, ok := frameCache.Load(f); ok {
+		return info.(FrameInfo), true
+	}
+	info := symbolize(f)
+	frameCache.Store(f, info)
+	return info, true
+}
+
+func symbolize(f Frame) FrameInfo {
+	fn := runtime.FuncForPC(f.pc())
+	if fn == nil {
+		return FrameInfo{Function: "unknown", File: "unknown"}
+	}
+	file, line := fn.FileLine(f.pc())
+	return FrameInfo{Function: fn.Name(), File: file, Line: line}
+}
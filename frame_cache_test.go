@@ -0,0 +1,59 @@
+package errors
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+)
+
+func Test_frame_cache_same_output(t *testing.T) {
+	err := New("cached")
+	want := fmt.Sprintf("%+v", err)
+
+	SetFrameCache(true)
+	defer SetFrameCache(false)
+
+	assert.Equal(t, want, fmt.Sprintf("%+v", err))
+	assert.Equal(t, want, fmt.Sprintf("%+v", err))
+}
+
+func Test_frame_cache_stores_frames(t *testing.T) {
+	SetFrameCache(true)
+	defer SetFrameCache(false)
+	frame := New("cached").StackTrace()[0]
+
+	_ = fmt.Sprintf("%+v", frame)
+
+	info, ok := frameCache.Load(frame)
+	assert.True(t, ok)
+	assert.Equal(t, frame.Info(), info)
+}
+
+func Test_frame_cache_disable_drops_frames(t *testing.T) {
+	SetFrameCache(true)
+	frame := New("cached").StackTrace()[0]
+	_ = frame.Info()
+
+	SetFrameCache(false)
+
+	_, ok := frameCache.Load(frame)
+	assert.False(t, ok)
+}
+
+func Test_frame_cache_concurrent_format(t *testing.T) {
+	SetFrameCache(true)
+	defer SetFrameCache(false)
+	err := New("cached")
+	want := fmt.Sprintf("%+v", err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Equal(t, want, fmt.Sprintf("%+v", err))
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,27 @@
+package errors
+
+import (
+	"sync"
+)
+
+var (
+	frameFormatterMu sync.RWMutex
+	frameFormatter   func(FrameInfo) string
+)
+
+// SetFrameFormatter sets the function that renders each frame of a stack
+// trace formatted with %+v, e.g. to print "file:line function" on a single
+// line. A nil formatter restores the default, which prints the function
+// name and "file:line" on separate lines. Formatting a single Frame is not
+// affected.
+func SetFrameFormatter(formatter func(FrameInfo) string) {
+	frameFormatterMu.Lock()
+	defer frameFormatterMu.Unlock()
+	frameFormatter = formatter
+}
+
+func currentFrameFormatter() func(FrameInfo) string {
+	frameFormatterMu.RLock()
+	defer frameFormatterMu.RUnlock()
+	return frameFormatter
+}
@@ -0,0 +1,69 @@
+package errors
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"path"
+	"strings"
+	"testing"
+)
+
+func singleLineFrame(info FrameInfo) string {
+	return fmt.Sprintf("%s:%d %s", path.Base(info.File), info.Line, funcname(info.Function))
+}
+
+func Test_frame_formatter_default(t *testing.T) {
+	lines := strings.Split(fmt.Sprintf("%+v", New("default")), "\n")
+
+	assert.Equal(t, packagePrefix+"Test_frame_formatter_default", lines[1])
+	assert.Regexp(t, `^\t.+/frame_format_test\.go:\d+$`, lines[2])
+}
+
+func Test_frame_formatter_custom(t *testing.T) {
+	SetFrameFormatter(singleLineFrame)
+	defer SetFrameFormatter(nil)
+	err := New("custom")
+
+	lines := strings.Split(fmt.Sprintf("%+v", err), "\n")
+
+	assert.Equal(t, "custom", lines[0])
+	assert.Regexp(t, `^frame_format_test\.go:\d+ Test_frame_formatter_custom$`, lines[1])
+	assert.Len(t, lines, len(err.StackTrace())+1)
+}
+
+func Test_frame_formatter_stack_trace(t *testing.T) {
+	SetFrameFormatter(singleLineFrame)
+	defer SetFrameFormatter(nil)
+	stack := New("custom").StackTrace()
+
+	lines := strings.Split(fmt.Sprintf("%+v", stack), "\n")
+
+	assert.Equal(t, "", lines[0])
+	assert.Regexp(t, `^frame_format_test\.go:\d+ Test_frame_formatter_stack_trace$`, lines[1])
+	assert.Len(t, lines, len(stack)+1)
+}
+
+func Test_frame_formatter_leaves_frame_alone(t *testing.T) {
+	frame := New("custom").StackTrace()[0]
+	want := fmt.Sprintf("%+v", frame)
+
+	SetFrameFormatter(singleLineFrame)
+	defer SetFrameFormatter(nil)
+
+	assert.Equal(t, want, fmt.Sprintf("%+v", frame))
+}
+
+func Test_frame_formatter_explicit_stack(t *testing.T) {
+	SetFrameFormatter(singleLineFrame)
+	defer SetFrameFormatter(nil)
+	err := WithExplicitStack(New("remote").Wrap("call"), []FrameInfo{
+		{Function: "main.handle", File: "/srv/main.go", Line: 12},
+	})
+
+	lines := strings.Split(fmt.Sprintf("%+v", err), "\n")
+
+	assert.Equal(t, "main.go:12 handle", lines[len(lines)-1])
+	for _, line := range lines {
+		assert.NotContains(t, line, "\t")
+	}
+}
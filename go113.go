@@ -11,8 +11,33 @@ import (
 //
 // An error is considered to match a target if it is equal to that target or if
 // it implements a method Is(error) bool such that Is(target) returns true.
+// A target marked with Deprecate also matches its replacement.
 func Is(err error, target error) bool {
-	return stderrors.Is(err, target)
+	matched, entry := matchDeprecated(err, target, stderrors.Is(err, target))
+	if entry != nil {
+		entry.warn()
+	}
+	return matched
+}
+
+// is reports whether err matches target like Is, but does not log the
+// warning of a deprecated target. Lookups within this package use it, so
+// they do not warn on behalf of callers that never checked for a
+// deprecated sentinel.
+func is(err error, target error) bool {
+	matched, _ := matchDeprecated(err, target, stderrors.Is(err, target))
+	return matched
+}
+
+// IsAny reports whether err matches any of the targets, as reported by Is.
+// It is a shorthand for Is(err, a) || Is(err, b) || ...
+func IsAny(err error, targets ...error) bool {
+	for _, target := range targets {
+		if Is(err, target) {
+			return true
+		}
+	}
+	return false
 }
 
 // As finds the first error in err's chain that matches target, and if so, sets
@@ -31,3 +56,16 @@ func Is(err error, target error) bool {
 func As(err error, target interface{}) bool {
 	return stderrors.As(err, target)
 }
+
+// AsAny tries As with each of the targets in order. It returns the index of
+// the first target that matched, and true, or -1 and false if none of them
+// matched. Like As, it panics if a target is not a non-nil pointer to a
+// type that implements error or to an interface type.
+func AsAny(err error, targets ...interface{}) (int, bool) {
+	for i, target := range targets {
+		if As(err, target) {
+			return i, true
+		}
+	}
+	return -1, false
+}
@@ -69,6 +69,42 @@ func TestIs(t *testing.T) {
 	}
 }
 
+func TestIsAny(t *testing.T) {
+	errNotFound := New("not found")
+	errGone := New("gone")
+	errForbidden := New("forbidden")
+	err := Wrap(WithStatus(errGone, 410), "load user")
+
+	tests := []struct {
+		name    string
+		targets []error
+		want    bool
+	}{
+		{
+			name:    "one of three sentinels",
+			targets: []error{errNotFound, errGone, errForbidden},
+			want:    true,
+		},
+		{
+			name:    "none of the sentinels",
+			targets: []error{errNotFound, errForbidden},
+			want:    false,
+		},
+		{
+			name:    "no targets",
+			targets: nil,
+			want:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsAny(err, tt.targets...); got != tt.want {
+				t.Errorf("IsAny() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 type customErr struct {
 	msg string
 }
@@ -134,6 +170,25 @@ func TestAs(t *testing.T) {
 	}
 }
 
+func TestAsAny(t *testing.T) {
+	err := Wrap(customErr{msg: "test message"}, "wrapped")
+
+	var status *withStatus
+	var custom customErr
+	i, ok := AsAny(err, &status, &custom)
+	if !ok || i != 1 {
+		t.Errorf("AsAny() = %v, %v, want 1, true", i, ok)
+	}
+	if custom.msg != "test message" {
+		t.Errorf("AsAny() target = %v, want %v", custom.msg, "test message")
+	}
+
+	i, ok = AsAny(err, &status)
+	if ok || i != -1 {
+		t.Errorf("AsAny() = %v, %v, want -1, false", i, ok)
+	}
+}
+
 func TestUnwrap(t *testing.T) {
 	err := New("test")
 
@@ -0,0 +1,21 @@
+module github.com/confetti-framework/errors/grpcerrors
+
+go 1.18
+
+require (
+	github.com/confetti-framework/errors v0.0.0
+	github.com/stretchr/testify v1.6.1
+	google.golang.org/grpc v1.56.3
+	google.golang.org/protobuf v1.30.0
+)
+
+require (
+	github.com/confetti-framework/syslog v0.1.0-rc // indirect
+	github.com/davecgh/go-spew v1.1.0 // indirect
+	github.com/golang/protobuf v1.5.3 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	google.golang.org/genproto v0.0.0-20230410155749-daa745c078e1 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20200313102051-9f266ea9e77c // indirect
+)
+
+replace github.com/confetti-framework/errors => ../
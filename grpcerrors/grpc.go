@@ -0,0 +1,86 @@
+// Package grpcerrors converts the errors of github.com/confetti-framework/errors
+// to gRPC statuses. It is a separate module, so the errors package itself
+// does not depend on gRPC.
+package grpcerrors
+
+import (
+	"github.com/confetti-framework/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	net "net/http"
+)
+
+// GRPCCode returns the gRPC code that corresponds to the HTTP status of err,
+// following the mapping of the gRPC HTTP gateway. It returns codes.OK for a
+// nil error and codes.Unknown for an error without a status.
+func GRPCCode(err error) codes.Code {
+	if err == nil {
+		return codes.OK
+	}
+	httpStatus, ok := errors.FindStatus(err)
+	if !ok {
+		return codes.Unknown
+	}
+	switch httpStatus {
+	case net.StatusBadRequest:
+		return codes.InvalidArgument
+	case net.StatusUnauthorized:
+		return codes.Unauthenticated
+	case net.StatusForbidden:
+		return codes.PermissionDenied
+	case net.StatusNotFound:
+		return codes.NotFound
+	case net.StatusConflict:
+		return codes.AlreadyExists
+	case net.StatusPreconditionFailed:
+		return codes.FailedPrecondition
+	case net.StatusTooManyRequests:
+		return codes.ResourceExhausted
+	case 499:
+		return codes.Canceled
+	case net.StatusNotImplemented:
+		return codes.Unimplemented
+	case net.StatusServiceUnavailable:
+		return codes.Unavailable
+	case net.StatusGatewayTimeout:
+		return codes.DeadlineExceeded
+	}
+	switch {
+	case httpStatus >= 200 && httpStatus < 300:
+		return codes.OK
+	case httpStatus >= 400 && httpStatus < 500:
+		return codes.FailedPrecondition
+	case httpStatus >= 500:
+		return codes.Internal
+	default:
+		return codes.Unknown
+	}
+}
+
+// GRPCStatus returns a gRPC status with the code of GRPCCode and the message
+// of err. The fields of err are attached as a structpb.Struct detail when
+// all of their values can be represented by it. A gRPC handler returns it
+// with
+//
+//	return nil, grpcerrors.GRPCStatus(err).Err()
+//
+// For a nil error the status is OK, of which Err returns nil.
+func GRPCStatus(err error) *status.Status {
+	if err == nil {
+		return status.New(codes.OK, "")
+	}
+	result := status.New(GRPCCode(err), err.Error())
+	fields := errors.FindFields(err)
+	if len(fields) == 0 {
+		return result
+	}
+	details, structErr := structpb.NewStruct(fields)
+	if structErr != nil {
+		return result
+	}
+	if withDetails, detailsErr := result.WithDetails(details); detailsErr == nil {
+		return withDetails
+	}
+	return result
+}
@@ -0,0 +1,71 @@
+package grpcerrors
+
+import (
+	"github.com/confetti-framework/errors"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+	"io"
+	"testing"
+)
+
+func Test_grpc_code(t *testing.T) {
+	tests := []struct {
+		err  error
+		want codes.Code
+	}{
+		{nil, codes.OK},
+		{io.EOF, codes.Unknown},
+		{errors.BadRequest("invalid"), codes.InvalidArgument},
+		{errors.Unauthorized("who are you"), codes.Unauthenticated},
+		{errors.Forbidden("not allowed"), codes.PermissionDenied},
+		{errors.NotFound("user not found"), codes.NotFound},
+		{errors.Conflict("user exists"), codes.AlreadyExists},
+		{errors.WithStatus(io.EOF, 429), codes.ResourceExhausted},
+		{errors.WithStatus(io.EOF, 418), codes.FailedPrecondition},
+		{errors.WithStatus(io.EOF, 503), codes.Unavailable},
+		{errors.WithStatus(io.EOF, 504), codes.DeadlineExceeded},
+		{errors.Internal("oops"), codes.Internal},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, GRPCCode(tt.err), "%v", tt.err)
+	}
+}
+
+func Test_grpc_status_round_trip(t *testing.T) {
+	err := errors.Wrap(errors.NotFound("user 12 not found"), "load user")
+
+	st, ok := status.FromError(GRPCStatus(err).Err())
+
+	assert.True(t, ok)
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Equal(t, "load user: user 12 not found", st.Message())
+	assert.Empty(t, st.Details())
+}
+
+func Test_grpc_status_nil(t *testing.T) {
+	assert.Nil(t, GRPCStatus(nil).Err())
+}
+
+func Test_grpc_status_details_from_fields(t *testing.T) {
+	err := errors.WithField(errors.NotFound("user not found"), "user_id", 12)
+
+	st, _ := status.FromError(GRPCStatus(err).Err())
+
+	details := st.Details()
+	if assert.Len(t, details, 1) {
+		fields, ok := details[0].(*structpb.Struct)
+		assert.True(t, ok)
+		assert.Equal(t, map[string]interface{}{"user_id": float64(12)}, fields.AsMap())
+	}
+}
+
+func Test_grpc_status_unsupported_fields(t *testing.T) {
+	err := errors.WithField(errors.NotFound("user not found"), "channel", make(chan int))
+
+	st, _ := status.FromError(GRPCStatus(err).Err())
+
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Empty(t, st.Details())
+}
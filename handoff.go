@@ -0,0 +1,74 @@
+package errors
+
+import (
+	"fmt"
+	syslog "github.com/confetti-framework/syslog/log_level"
+)
+
+// Handoff annotates err with the frame of its caller, to be called where an
+// error is received from another goroutine, e.g. over a channel. The stack
+// trace of err keeps showing where the error was produced, while
+// FindHandoff shows where it was handed off. If err is nil, Handoff returns
+// nil.
+func Handoff(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &withHandoff{
+		cause: err,
+		frame: Frame(callerPC(1)),
+	}
+}
+
+// FindHandoff returns the frame of the outermost Handoff in the chain of
+// err.
+func FindHandoff(err error) (FrameInfo, bool) {
+	var handoffHolder *withHandoff
+
+	if !As(err, &handoffHolder) || handoffHolder.frame == 0 {
+		return FrameInfo{}, false
+	}
+
+	return handoffHolder.frame.Info(), true
+}
+
+type withHandoff struct {
+	cause error
+	frame Frame
+}
+
+func (w *withHandoff) Error() string {
+	return w.cause.Error()
+}
+
+func (w *withHandoff) Format(st fmt.State, verb rune) {
+	formatCause(st, verb, w, w.cause)
+}
+
+func (w *withHandoff) Unwrap() error {
+	return w.cause
+}
+
+func (w *withHandoff) Cause() error {
+	return Unwrap(w)
+}
+
+func (w *withHandoff) Wrap(message string, args ...interface{}) *withMessage {
+	return newMessage(w, message, args...)
+}
+
+func (w *withHandoff) Level(level syslog.Level) *withLevel {
+	return withLevelAt(w, level, 1)
+}
+
+func (w *withHandoff) Status(status int) *withStatus {
+	return WithStatus(w, status)
+}
+
+func (w *withHandoff) Alert() *withAlert {
+	return &withAlert{w}
+}
+
+func (w *withHandoff) With(key string, value interface{}) *withField {
+	return &withField{cause: w, key: key, value: value}
+}
@@ -0,0 +1,43 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func produce(errs chan<- error) {
+	errs <- New("produced")
+}
+
+func consume(errs <-chan error) error {
+	return Handoff(<-errs)
+}
+
+func Test_handoff_nil(t *testing.T) {
+	assert.Nil(t, Handoff(nil))
+}
+
+func Test_handoff_producer_consumer(t *testing.T) {
+	errs := make(chan error)
+	go produce(errs)
+
+	err := consume(errs)
+
+	assert.EqualError(t, err, "produced")
+	handoff, ok := FindHandoff(err)
+	assert.True(t, ok)
+	assert.Equal(t, "consume", funcname(handoff.Function))
+	assert.Regexp(t, `/handoff_test\.go$`, handoff.File)
+	assert.True(t, handoff.Line > 0)
+
+	stack, ok := FindStack(err)
+	assert.True(t, ok)
+	assert.Equal(t, "produce", funcname(stack[0].name()))
+}
+
+func Test_find_handoff_without_handoff(t *testing.T) {
+	_, ok := FindHandoff(Wrap(io.EOF, "read"))
+
+	assert.False(t, ok)
+}
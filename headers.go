@@ -0,0 +1,85 @@
+package errors
+
+import (
+	"fmt"
+	syslog "github.com/confetti-framework/syslog/log_level"
+	net "net/http"
+	"net/textproto"
+)
+
+// WithHeaders annotates err with HTTP response headers, such as Retry-After
+// or WWW-Authenticate, that are written by WriteHTTP.
+// If err is nil, WithHeaders returns nil.
+func WithHeaders(err error, h net.Header) error {
+	if err == nil {
+		return nil
+	}
+	return &withHeaders{
+		cause:   err,
+		headers: h.Clone(),
+	}
+}
+
+// FindHeaders returns the headers of all layers in the chain of err merged
+// together. When a header is set on multiple layers, the values of the
+// outermost layer replace those of the inner layers.
+func FindHeaders(err error) (net.Header, bool) {
+	var headers net.Header
+	for _, link := range Links(err) {
+		holder, ok := link.(*withHeaders)
+		if !ok {
+			continue
+		}
+		if headers == nil {
+			headers = net.Header{}
+		}
+		for key, values := range holder.headers {
+			key = textproto.CanonicalMIMEHeaderKey(key)
+			if _, exists := headers[key]; !exists {
+				headers[key] = append([]string(nil), values...)
+			}
+		}
+	}
+	return headers, headers != nil
+}
+
+type withHeaders struct {
+	cause   error
+	headers net.Header
+}
+
+func (w *withHeaders) Error() string {
+	return w.cause.Error()
+}
+
+func (w *withHeaders) Format(st fmt.State, verb rune) {
+	formatCause(st, verb, w, w.cause)
+}
+
+func (w *withHeaders) Unwrap() error {
+	return w.cause
+}
+
+func (w *withHeaders) Cause() error {
+	return Unwrap(w)
+}
+
+func (w *withHeaders) Wrap(message string, args ...interface{}) *withMessage {
+	return newMessage(w, message, args...)
+}
+
+func (w *withHeaders) Level(level syslog.Level) *withLevel {
+	return withLevelAt(w, level, 1)
+}
+
+func (w *withHeaders) Status(status int) *withStatus {
+	return WithStatus(w, status)
+}
+
+func (w *withHeaders) Alert() *withAlert {
+	return &withAlert{w}
+}
+
+func (w *withHeaders) With(key string, value interface{}) *withField {
+	return &withField{cause: w, key: key, value: value}
+}
@@ -0,0 +1,48 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	net "net/http"
+	"testing"
+)
+
+func Test_headers_with_nil(t *testing.T) {
+	assert.Nil(t, WithHeaders(nil, net.Header{"Retry-After": {"120"}}))
+}
+
+func Test_headers_without_headers(t *testing.T) {
+	headers, ok := FindHeaders(New("not found"))
+	assert.False(t, ok)
+	assert.Nil(t, headers)
+}
+
+func Test_headers_from_unwrap(t *testing.T) {
+	err := Wrap(WithHeaders(io.EOF, net.Header{"Retry-After": {"120"}}), "service unavailable")
+
+	headers, ok := FindHeaders(err)
+	assert.True(t, ok)
+	assert.Equal(t, "120", headers.Get("Retry-After"))
+}
+
+func Test_headers_outer_overrides_per_key(t *testing.T) {
+	inner := WithHeaders(io.EOF, net.Header{"Retry-After": {"120"}, "X-Reason": {"maintenance"}})
+	err := WithHeaders(inner, net.Header{"retry-after": {"60"}})
+
+	headers, ok := FindHeaders(err)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"60"}, headers["Retry-After"])
+	assert.Equal(t, []string{"maintenance"}, headers["X-Reason"])
+}
+
+func Test_headers_are_copied(t *testing.T) {
+	original := net.Header{"Retry-After": {"120"}}
+	err := WithHeaders(io.EOF, original)
+	original.Set("Retry-After", "60")
+
+	headers, _ := FindHeaders(err)
+	headers.Set("Retry-After", "30")
+
+	headers, _ = FindHeaders(err)
+	assert.Equal(t, "120", headers.Get("Retry-After"))
+}
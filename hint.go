@@ -0,0 +1,78 @@
+package errors
+
+import (
+	"fmt"
+	syslog "github.com/confetti-framework/syslog/log_level"
+	"io"
+)
+
+// WithHint annotates err with a hint for developers and operators on how to
+// fix it, e.g. "check the DATABASE_URL env var". The hint is not part of
+// the message; it only shows up in %+v output, and therefore in the debug
+// output of RenderHTML. If err is nil, WithHint returns nil.
+func WithHint(err error, hint string) error {
+	if err == nil {
+		return nil
+	}
+	return &withHint{
+		err,
+		hint,
+	}
+}
+
+// FindHint returns the outermost hint in the chain of err.
+func FindHint(err error) (string, bool) {
+	var hintHolder *withHint
+
+	if !As(err, &hintHolder) {
+		return "", false
+	}
+
+	return hintHolder.hint, true
+}
+
+type withHint struct {
+	cause error
+	hint  string
+}
+
+func (w *withHint) Error() string {
+	return w.cause.Error()
+}
+
+func (w *withHint) Format(st fmt.State, verb rune) {
+	if verb == 'v' && st.Flag('+') {
+		fmt.Fprintf(st, "%+v\nhint: ", w.cause)
+		io.WriteString(st, w.hint)
+		return
+	}
+	formatCause(st, verb, w, w.cause)
+}
+
+func (w *withHint) Unwrap() error {
+	return w.cause
+}
+
+func (w *withHint) Cause() error {
+	return Unwrap(w)
+}
+
+func (w *withHint) Wrap(message string, args ...interface{}) *withMessage {
+	return newMessage(w, message, args...)
+}
+
+func (w *withHint) Level(level syslog.Level) *withLevel {
+	return withLevelAt(w, level, 1)
+}
+
+func (w *withHint) Status(status int) *withStatus {
+	return WithStatus(w, status)
+}
+
+func (w *withHint) Alert() *withAlert {
+	return &withAlert{w}
+}
+
+func (w *withHint) With(key string, value interface{}) *withField {
+	return &withField{cause: w, key: key, value: value}
+}
@@ -0,0 +1,47 @@
+package errors
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+)
+
+func Test_with_hint_nil(t *testing.T) {
+	assert.Nil(t, WithHint(nil, "check the DATABASE_URL env var"))
+}
+
+func Test_find_hint(t *testing.T) {
+	err := Wrap(WithHint(io.EOF, "check the DATABASE_URL env var"), "connect")
+
+	hint, ok := FindHint(err)
+	assert.True(t, ok)
+	assert.Equal(t, "check the DATABASE_URL env var", hint)
+
+	_, ok = FindHint(io.EOF)
+	assert.False(t, ok)
+}
+
+func Test_hint_only_in_verbose_output(t *testing.T) {
+	err := WithHint(Wrap(io.EOF, "connect"), "check the DATABASE_URL env var")
+
+	assert.Equal(t, "connect: EOF", err.Error())
+	assert.Equal(t, "connect: EOF", fmt.Sprintf("%s", err))
+	assert.Equal(t, "connect: EOF", fmt.Sprintf("%v", err))
+	assert.True(t, strings.HasSuffix(fmt.Sprintf("%+v", err), "\nhint: check the DATABASE_URL env var"))
+	data, _ := MarshalJSON(err)
+	assert.NotContains(t, string(data), "hint")
+}
+
+func Test_hint_in_debug_html(t *testing.T) {
+	err := WithHint(io.EOF, "check the DATABASE_URL env var")
+
+	_, page := RenderHTML(err)
+	assert.NotContains(t, string(page), "DATABASE_URL")
+
+	SetDebugMode(true)
+	defer SetDebugMode(false)
+	_, page = RenderHTML(err)
+	assert.Contains(t, string(page), "hint: check the DATABASE_URL env var")
+}
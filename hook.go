@@ -0,0 +1,45 @@
+package errors
+
+import (
+	"sync/atomic"
+)
+
+// errorHook holds a hookHolder with the registered hook.
+var errorHook atomic.Value
+
+// hookHolder gives every hook stored in errorHook the same concrete type,
+// as atomic.Value requires.
+type hookHolder struct {
+	hook func(error) error
+}
+
+// SetErrorHook registers a transform that is applied to every error created
+// by Wrap, e.g. to attach a service name to all errors. The hook receives
+// the new error and should return it, optionally wrapped; Wrap then wraps
+// the result with its stack trace, so the error returned by the hook is
+// part of the chain and determines Error(). A nil hook, or a hook that
+// returns nil, leaves errors unchanged.
+//
+// New is not passed to the hook, since it returns a *fundamental to allow
+// chaining, which leaves no place for the error returned by the hook.
+//
+// The hook must not call Wrap, directly or indirectly: the error it creates
+// would be passed to the hook again, without end. Annotate the error with
+// WithField, WithCode and the other functions that do not call the hook.
+func SetErrorHook(hook func(error) error) {
+	errorHook.Store(hookHolder{hook})
+}
+
+// applyHook runs the registered hook on err. It returns nil if no hook is
+// registered, or if the hook returns nil or err itself.
+func applyHook(err error) error {
+	holder, _ := errorHook.Load().(hookHolder)
+	if holder.hook == nil {
+		return nil
+	}
+	hooked := holder.hook(err)
+	if hooked == err {
+		return nil
+	}
+	return hooked
+}
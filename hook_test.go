@@ -0,0 +1,98 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func Test_hook_not_applied_to_new(t *testing.T) {
+	SetErrorHook(func(err error) error { return WithCode(err, "billing") })
+	defer SetErrorHook(nil)
+
+	err := New("invoice %d not found", 1)
+
+	_, ok := FindCode(err)
+	assert.False(t, ok)
+	assert.Equal(t, "invoice 1 not found", err.Error())
+}
+
+func Test_hook_applied_to_wrap(t *testing.T) {
+	SetErrorHook(func(err error) error { return WithCode(err, "billing") })
+	defer SetErrorHook(nil)
+
+	err := Wrap(io.EOF, "read failed")
+
+	code, ok := FindCode(err)
+	assert.True(t, ok)
+	assert.Equal(t, "billing", code)
+	assert.Equal(t, "read failed: EOF", err.Error())
+	assert.True(t, Is(err, io.EOF))
+}
+
+func Test_hook_result_is_part_of_the_chain(t *testing.T) {
+	SetErrorHook(func(err error) error { return WithMessage(err, "billing") })
+	defer SetErrorHook(nil)
+
+	err := Wrap(io.EOF, "read failed")
+
+	assert.Equal(t, "billing: read failed: EOF", err.Error())
+	assert.IsType(t, &withMessage{}, Links(err)[1])
+	assert.Equal(t, "billing", Links(err)[1].(*withMessage).msg)
+}
+
+func Test_hook_runs_once_per_error(t *testing.T) {
+	calls := 0
+	SetErrorHook(func(err error) error {
+		calls++
+		return err
+	})
+	defer SetErrorHook(nil)
+
+	New("not found").Wrap("database error")
+	Wrap(io.EOF, "read failed")
+
+	assert.Equal(t, 1, calls)
+}
+
+func Test_hook_nil(t *testing.T) {
+	SetErrorHook(nil)
+
+	err := Wrap(io.EOF, "read failed")
+
+	assert.Len(t, Links(err), 3)
+}
+
+func Test_hook_returning_nil(t *testing.T) {
+	SetErrorHook(func(err error) error { return nil })
+	defer SetErrorHook(nil)
+
+	assert.Equal(t, "database error: EOF", Wrap(io.EOF, "database error").Error())
+}
+
+func Test_hook_applied_while_running_on_other_goroutine(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	SetErrorHook(func(err error) error {
+		if err.Error() == "slow: EOF" {
+			close(started)
+			<-release
+		}
+		return WithCode(err, "billing")
+	})
+	defer SetErrorHook(nil)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		Wrap(io.EOF, "slow")
+	}()
+	<-started
+	err := Wrap(io.EOF, "fast")
+	close(release)
+	<-done
+
+	code, ok := FindCode(err)
+	assert.True(t, ok)
+	assert.Equal(t, "billing", code)
+}
@@ -0,0 +1,30 @@
+package errors
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+)
+
+// RenderHTML returns the status of err and a minimal HTML error page for it.
+// The page shows the status text and, if present, the public message of
+// err (see WithPublicMessage). Internal messages and stack traces are only
+// included when debug mode is enabled with SetDebugMode.
+func RenderHTML(err error) (statusCode int, page []byte) {
+	statusCode, _ = FindStatus(err)
+	title := fmt.Sprintf("%d %s", statusCode, StatusText(err))
+
+	var b bytes.Buffer
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n", html.EscapeString(title))
+	b.WriteString("</head>\n<body>\n")
+	fmt.Fprintf(&b, "<h1>%s</h1>\n", html.EscapeString(title))
+	if public, ok := FindPublicMessage(err); ok {
+		fmt.Fprintf(&b, "<p>%s</p>\n", html.EscapeString(public))
+	}
+	if debugModeEnabled() && err != nil {
+		fmt.Fprintf(&b, "<pre>%s</pre>\n", html.EscapeString(fmt.Sprintf("%+v", err)))
+	}
+	b.WriteString("</body>\n</html>\n")
+	return statusCode, b.Bytes()
+}
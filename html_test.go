@@ -0,0 +1,41 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	net "net/http"
+	"testing"
+)
+
+func Test_render_html(t *testing.T) {
+	SetDebugMode(false)
+	err := WithPublicMessage(NotFound("user 12 not found in table <users>"), "We could not find that <user>.")
+
+	status, page := RenderHTML(err)
+
+	assert.Equal(t, net.StatusNotFound, status)
+	assert.Contains(t, string(page), "<title>404 Not Found</title>")
+	assert.Contains(t, string(page), "<p>We could not find that &lt;user&gt;.</p>")
+	assert.NotContains(t, string(page), "table")
+	assert.NotContains(t, string(page), "html_test.go")
+}
+
+func Test_render_html_without_status(t *testing.T) {
+	SetDebugMode(false)
+
+	status, page := RenderHTML(New("database unreachable"))
+
+	assert.Equal(t, net.StatusInternalServerError, status)
+	assert.Contains(t, string(page), "<h1>500 Internal Server Error</h1>")
+	assert.NotContains(t, string(page), "database unreachable")
+	assert.NotContains(t, string(page), "<p>")
+}
+
+func Test_render_html_debug_mode(t *testing.T) {
+	SetDebugMode(true)
+	defer SetDebugMode(false)
+
+	_, page := RenderHTML(NotFound("user 12 not found in table <users>"))
+
+	assert.Contains(t, string(page), "<pre>user 12 not found in table &lt;users&gt;")
+	assert.Contains(t, string(page), "html_test.go")
+}
@@ -0,0 +1,158 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	net "net/http"
+)
+
+// StatusText returns the HTTP reason phrase of the status of err, as
+// resolved by FindStatus. Without a status it returns the text of 500.
+func StatusText(err error) string {
+	status, _ := FindStatus(err)
+	if text := net.StatusText(status); text != "" {
+		return text
+	}
+	return net.StatusText(net.StatusInternalServerError)
+}
+
+// BadRequest returns a new error with the supplied message and status 400.
+func BadRequest(message string, args ...interface{}) *withStatus {
+	return WithStatus(newFundamental(callers(), message, args...), net.StatusBadRequest)
+}
+
+// Unauthorized returns a new error with the supplied message and status 401.
+func Unauthorized(message string, args ...interface{}) *withStatus {
+	return WithStatus(newFundamental(callers(), message, args...), net.StatusUnauthorized)
+}
+
+// Forbidden returns a new error with the supplied message and status 403.
+func Forbidden(message string, args ...interface{}) *withStatus {
+	return WithStatus(newFundamental(callers(), message, args...), net.StatusForbidden)
+}
+
+// NotFound returns a new error with the supplied message and status 404.
+func NotFound(message string, args ...interface{}) *withStatus {
+	return WithStatus(newFundamental(callers(), message, args...), net.StatusNotFound)
+}
+
+// Conflict returns a new error with the supplied message and status 409.
+func Conflict(message string, args ...interface{}) *withStatus {
+	return WithStatus(newFundamental(callers(), message, args...), net.StatusConflict)
+}
+
+// Internal returns a new error with the supplied message and status 500.
+func Internal(message string, args ...interface{}) *withStatus {
+	return WithStatus(newFundamental(callers(), message, args...), net.StatusInternalServerError)
+}
+
+// WriteHTTP writes err as an HTTP response. It applies the headers found by
+// FindHeaders and the Retry-After header for FindRetryAfter (unless that
+// header is set explicitly), and writes the status found by FindStatus with
+// its status text as body, unless HasResponseBody reports that the status
+// does not allow one. The message of err is not written, since it may
+// contain internal details.
+func WriteHTTP(w net.ResponseWriter, err error) {
+	if headers, ok := FindHeaders(err); ok {
+		for key, values := range headers {
+			w.Header()[key] = values
+		}
+	}
+	if retryAfter, ok := FindRetryAfter(err); ok && w.Header().Get("Retry-After") == "" {
+		w.Header().Set("Retry-After", retryAfterSeconds(retryAfter))
+	}
+	status, _ := FindStatus(err)
+	if !HasResponseBody(err) {
+		w.WriteHeader(status)
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	fmt.Fprintln(w, StatusText(err))
+}
+
+// Response returns everything a handler needs to respond with err: the
+// status found by FindStatus, and a JSON body with its content type. The
+// body is the encoding of ClientError, so it only holds the public message
+// (or the status text), the status, the codes, the messages per field of a
+// validation error and the request ID; in debug
+// mode (see SetDebugMode) it is the full encoding of MarshalJSON instead.
+// If HasResponseBody reports that the status does not allow a body, the
+// content type and body are empty.
+func Response(err error) (status int, contentType string, body []byte) {
+	status, _ = FindStatus(err)
+	if !HasResponseBody(err) {
+		return status, "", nil
+	}
+	body, marshalErr := MarshalJSON(responseError(err))
+	if marshalErr != nil {
+		body, _ = json.Marshal(jsonError{Message: StatusText(err), Status: status})
+	}
+	return status, "application/json; charset=utf-8", body
+}
+
+// responseError returns the error Response encodes for err.
+func responseError(err error) error {
+	if debugModeEnabled() {
+		return err
+	}
+	return ClientError(err)
+}
+
+// HasResponseBody reports whether a response with the status found by
+// FindStatus may have a body. Informational statuses, 204 No Content and
+// 304 Not Modified must not have one.
+func HasResponseBody(err error) bool {
+	status, _ := FindStatus(err)
+	switch {
+	case status >= 100 && status < 200:
+		return false
+	case status == net.StatusNoContent, status == net.StatusNotModified:
+		return false
+	default:
+		return true
+	}
+}
+
+// StatusCoder is implemented by errors that carry an HTTP status, as
+// expected by various web frameworks. Only the layers created by WithStatus
+// implement it: a type assertion fails when such a layer is wrapped by
+// another one, and a status registered with RegisterStatus is not covered
+// at all, even though FindStatus reports a status in both cases. Use
+// AsStatusError to get a StatusCoder for any error; WriteHTTP and Response
+// rely on FindStatus alone.
+type StatusCoder interface {
+	StatusCode() int
+}
+
+// AsStatusError returns err wrapped in an error that implements StatusCoder
+// by resolving FindStatus, so it reports 500 when err has no status.
+// If err is nil, AsStatusError returns nil.
+func AsStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &statusError{err}
+}
+
+type statusError struct {
+	cause error
+}
+
+func (s *statusError) Error() string {
+	return s.cause.Error()
+}
+
+func (s *statusError) Format(st fmt.State, verb rune) {
+	formatCause(st, verb, s, s.cause)
+}
+
+func (s *statusError) Unwrap() error {
+	return s.cause
+}
+
+func (s *statusError) StatusCode() int {
+	status, _ := FindStatus(s.cause)
+	return status
+}
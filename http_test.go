@@ -0,0 +1,190 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	net "net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func Test_status_text(t *testing.T) {
+	err := New("user not found").Status(net.StatusNotFound)
+	assert.Equal(t, "Not Found", StatusText(err.Wrap("database error")))
+}
+
+func Test_status_text_without_status(t *testing.T) {
+	assert.Equal(t, "Internal Server Error", StatusText(New("database error")))
+}
+
+func Test_status_text_unknown_status(t *testing.T) {
+	assert.Equal(t, "Internal Server Error", StatusText(New("teapot").Status(999)))
+}
+
+func Test_status_constructors(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{BadRequest("invalid %s", "name"), net.StatusBadRequest},
+		{Unauthorized("invalid %s", "token"), net.StatusUnauthorized},
+		{Forbidden("access denied"), net.StatusForbidden},
+		{NotFound("user %d not found", 1), net.StatusNotFound},
+		{Conflict("user already exists"), net.StatusConflict},
+		{Internal("database unreachable"), net.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		status, ok := FindStatus(tt.err)
+		assert.True(t, ok)
+		assert.Equal(t, tt.want, status)
+	}
+}
+
+func Test_status_constructor_message(t *testing.T) {
+	err := NotFound("user %d not found", 1)
+
+	assert.Equal(t, "user 1 not found", err.Error())
+	assert.Equal(t, "database error: user 1 not found", err.Wrap("database error").Error())
+}
+
+func Test_status_constructor_stack(t *testing.T) {
+	stack, ok := FindStack(NotFound("user not found"))
+
+	assert.True(t, ok)
+	assert.Equal(t, "Test_status_constructor_stack", funcname(stack[0].name()))
+}
+
+func Test_write_http(t *testing.T) {
+	recorder := httptest.NewRecorder()
+
+	WriteHTTP(recorder, NotFound("user 12 not found in table users"))
+
+	assert.Equal(t, net.StatusNotFound, recorder.Code)
+	assert.Equal(t, "Not Found\n", recorder.Body.String())
+	assert.Equal(t, "text/plain; charset=utf-8", recorder.Header().Get("Content-Type"))
+}
+
+func Test_write_http_with_headers(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	err := WithHeaders(New("maintenance").Status(net.StatusServiceUnavailable), net.Header{"Retry-After": {"120"}})
+
+	WriteHTTP(recorder, err)
+
+	assert.Equal(t, net.StatusServiceUnavailable, recorder.Code)
+	assert.Equal(t, "120", recorder.Header().Get("Retry-After"))
+}
+
+func Test_has_response_body(t *testing.T) {
+	assert.False(t, HasResponseBody(WithStatus(io.EOF, net.StatusNoContent)))
+	assert.False(t, HasResponseBody(WithStatus(io.EOF, net.StatusNotModified)))
+	assert.False(t, HasResponseBody(WithStatus(io.EOF, net.StatusContinue)))
+	assert.True(t, HasResponseBody(NotFound("user not found")))
+	assert.True(t, HasResponseBody(Internal("oops")))
+	assert.True(t, HasResponseBody(io.EOF))
+}
+
+func Test_write_http_without_body(t *testing.T) {
+	recorder := httptest.NewRecorder()
+
+	WriteHTTP(recorder, WithStatus(io.EOF, net.StatusNotModified))
+
+	assert.Equal(t, net.StatusNotModified, recorder.Code)
+	assert.Equal(t, "", recorder.Body.String())
+	assert.Equal(t, "", recorder.Header().Get("Content-Type"))
+}
+
+func Test_response_not_found_with_public_message(t *testing.T) {
+	err := WithPublicMessage(NotFound("select from users: no rows"), "user not found")
+
+	status, contentType, body := Response(err)
+
+	assert.Equal(t, net.StatusNotFound, status)
+	assert.Equal(t, "application/json; charset=utf-8", contentType)
+	assert.JSONEq(t, `{"message":"user not found","status":404}`, string(body))
+}
+
+func Test_response_internal_without_debug_mode(t *testing.T) {
+	err := WithField(Wrap(io.EOF, "read config"), "path", "/etc/app")
+
+	status, _, body := Response(err)
+
+	assert.Equal(t, net.StatusInternalServerError, status)
+	assert.JSONEq(t, `{"message":"Internal Server Error"}`, string(body))
+}
+
+func Test_response_internal_in_debug_mode(t *testing.T) {
+	SetDebugMode(true)
+	defer SetDebugMode(false)
+	err := WithField(Wrap(io.EOF, "read config"), "path", "/etc/app")
+
+	status, _, body := Response(err)
+
+	assert.Equal(t, net.StatusInternalServerError, status)
+	assert.JSONEq(t, `{"message":"read config: EOF","fields":{"path":"/etc/app"}}`, string(body))
+}
+
+func Test_response_validation_error(t *testing.T) {
+	err := Wrap(NewValidation(map[string]string{"email": "is required"}), "register user")
+
+	status, _, body := Response(err)
+
+	assert.Equal(t, net.StatusUnprocessableEntity, status)
+	assert.JSONEq(t, `{"message":"Unprocessable Entity","status":422,"errors":{"email":"is required"}}`, string(body))
+}
+
+func Test_response_without_body(t *testing.T) {
+	status, contentType, body := Response(WithStatus(io.EOF, net.StatusNoContent))
+
+	assert.Equal(t, net.StatusNoContent, status)
+	assert.Equal(t, "", contentType)
+	assert.Nil(t, body)
+}
+
+func Test_with_status_implements_status_coder(t *testing.T) {
+	var err error = WithStatus(io.EOF, net.StatusNotFound)
+
+	coder, ok := err.(StatusCoder)
+	assert.True(t, ok)
+	assert.Equal(t, net.StatusNotFound, coder.StatusCode())
+}
+
+func Test_as_status_error_nil(t *testing.T) {
+	assert.Nil(t, AsStatusError(nil))
+}
+
+func Test_as_status_error(t *testing.T) {
+	err := AsStatusError(NotFound("user not found").Wrap("database error"))
+
+	coder, ok := err.(StatusCoder)
+	assert.True(t, ok)
+	assert.Equal(t, net.StatusNotFound, coder.StatusCode())
+	assert.Equal(t, "database error: user not found", err.Error())
+}
+
+func Test_as_status_error_without_status(t *testing.T) {
+	err := AsStatusError(io.EOF)
+
+	assert.Equal(t, net.StatusInternalServerError, err.(StatusCoder).StatusCode())
+	assert.True(t, Is(err, io.EOF))
+}
+
+func Test_status_coder_found_with_as(t *testing.T) {
+	var coder StatusCoder
+
+	assert.True(t, As(Wrap(WithStatus(io.EOF, net.StatusConflict), "save failed"), &coder))
+	assert.Equal(t, net.StatusConflict, coder.StatusCode())
+}
+
+func Test_status_coder_not_implemented_by_registered_status(t *testing.T) {
+	t.Cleanup(resetRegistry)
+	sentinel := New("registered gone")
+	RegisterStatus(sentinel, net.StatusGone)
+	err := Wrap(sentinel, "load user")
+
+	var coder StatusCoder
+	assert.False(t, As(err, &coder))
+	assert.Equal(t, net.StatusGone, AsStatusError(err).(StatusCoder).StatusCode())
+	status, _, _ := Response(err)
+	assert.Equal(t, net.StatusGone, status)
+}
@@ -0,0 +1,100 @@
+package errors
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	maxJoinRenderMu sync.RWMutex
+	maxJoinRender   int
+)
+
+// SetMaxJoinRender limits the number of messages the message of a joined
+// error consists of to n. The remaining messages are summarized as
+// "and N more"; Unwrap still returns all errors. A limit of 0 or less
+// renders all messages, which is the default.
+func SetMaxJoinRender(n int) {
+	maxJoinRenderMu.Lock()
+	defer maxJoinRenderMu.Unlock()
+	maxJoinRender = n
+}
+
+func currentMaxJoinRender() int {
+	maxJoinRenderMu.RLock()
+	defer maxJoinRenderMu.RUnlock()
+	return maxJoinRender
+}
+
+// Join returns an error that wraps the given errors. Nil errors are
+// discarded; Join returns nil if every error is nil. The message of the
+// joined error consists of the messages of the wrapped errors, separated by
+// newlines, limited by SetMaxJoinRender. Is and As match against each of
+// the wrapped errors.
+func Join(errs ...error) error {
+	var joined []error
+	for _, err := range errs {
+		if err != nil {
+			joined = append(joined, err)
+		}
+	}
+	if len(joined) == 0 {
+		return nil
+	}
+	return &joinError{errs: joined}
+}
+
+type joinError struct {
+	errs []error
+}
+
+func (j *joinError) Error() string {
+	rendered := j.errs
+	if limit := currentMaxJoinRender(); limit > 0 && len(rendered) > limit {
+		rendered = rendered[:limit]
+	}
+	messages := make([]string, len(rendered), len(rendered)+1)
+	for i, err := range rendered {
+		messages[i] = err.Error()
+	}
+	if more := len(j.errs) - len(rendered); more > 0 {
+		messages = append(messages, "and "+strconv.Itoa(more)+" more")
+	}
+	return strings.Join(messages, "\n")
+}
+
+func (j *joinError) Unwrap() []error {
+	return j.errs
+}
+
+// Is and As make joined errors work with toolchains whose standard library
+// does not know about Unwrap() []error.
+func (j *joinError) Is(target error) bool {
+	for _, err := range j.errs {
+		if is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (j *joinError) As(target interface{}) bool {
+	for _, err := range j.errs {
+		if As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// Coalesce returns the first of errs that is not nil, or nil if every error
+// is nil.
+func Coalesce(errs ...error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
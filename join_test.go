@@ -0,0 +1,80 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	net "net/http"
+	"testing"
+)
+
+func Test_join_nil(t *testing.T) {
+	assert.Nil(t, Join())
+	assert.Nil(t, Join(nil, nil))
+}
+
+func Test_join_message(t *testing.T) {
+	err := Join(New("first"), nil, Wrap(io.EOF, "second"))
+
+	assert.Equal(t, "first\nsecond: EOF", err.Error())
+}
+
+func Test_join_is(t *testing.T) {
+	err := Join(New("first"), Wrap(io.EOF, "second"))
+
+	assert.True(t, Is(err, io.EOF))
+	assert.False(t, Is(err, io.ErrUnexpectedEOF))
+}
+
+func Test_join_as(t *testing.T) {
+	err := Join(New("first"), NotFound("second"))
+
+	status, ok := FindStatus(err)
+	assert.True(t, ok)
+	assert.Equal(t, net.StatusNotFound, status)
+}
+
+func Test_coalesce_all_nil(t *testing.T) {
+	assert.Nil(t, Coalesce())
+	assert.Nil(t, Coalesce(nil, nil))
+}
+
+func Test_coalesce_first_non_nil(t *testing.T) {
+	first := New("first")
+
+	assert.Equal(t, first, Coalesce(first, nil, io.EOF))
+}
+
+func Test_coalesce_middle_non_nil(t *testing.T) {
+	assert.Equal(t, io.EOF, Coalesce(nil, io.EOF, io.ErrUnexpectedEOF, nil))
+}
+
+func Test_max_join_render(t *testing.T) {
+	SetMaxJoinRender(2)
+	defer SetMaxJoinRender(0)
+	var errs []error
+	for i := 1; i <= 100; i++ {
+		errs = append(errs, New("error %d", i))
+	}
+
+	err := Join(errs...)
+
+	assert.Equal(t, "error 1\nerror 2\nand 98 more", err.Error())
+	assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 100)
+}
+
+func Test_max_join_render_not_reached(t *testing.T) {
+	SetMaxJoinRender(2)
+	defer SetMaxJoinRender(0)
+
+	assert.Equal(t, "first\nsecond", Join(New("first"), New("second")).Error())
+}
+
+func Test_max_join_render_collector(t *testing.T) {
+	SetMaxJoinRender(1)
+	defer SetMaxJoinRender(0)
+	var errs Errors
+	errs.Append(New("first"))
+	errs.Append(New("second"))
+
+	assert.Equal(t, "first\nand 1 more", errs.Error())
+}
@@ -0,0 +1,145 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/confetti-framework/syslog"
+	"io"
+	"sync/atomic"
+)
+
+var includeChain int32
+
+// IncludeChain controls whether MarshalJSON adds a "chain" array that
+// describes every layer of the error, from the outermost to the root cause.
+// It is off by default.
+func IncludeChain(enabled bool) {
+	var mode int32
+	if enabled {
+		mode = 1
+	}
+	atomic.StoreInt32(&includeChain, mode)
+}
+
+func includeChainEnabled() bool {
+	return atomic.LoadInt32(&includeChain) == 1
+}
+
+// jsonError is the JSON representation of an error.
+type jsonError struct {
+	Message   string                 `json:"message"`
+	Status    int                    `json:"status,omitempty"`
+	Level     string                 `json:"level,omitempty"`
+	Code      string                 `json:"code,omitempty"`
+	ErrorCode *int                   `json:"error_code,omitempty"`
+	Fields    map[string]interface{} `json:"fields,omitempty"`
+	Errors    map[string]string      `json:"errors,omitempty"`
+	Chain     []jsonLayer            `json:"chain,omitempty"`
+}
+
+// jsonLayer is the JSON representation of a single layer of an error. The
+// message is the part of the message the layer adds to its cause.
+type jsonLayer struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+	Status  int    `json:"status,omitempty"`
+	Level   string `json:"level,omitempty"`
+}
+
+// MarshalJSON returns the JSON encoding of err: its message together with
+// the status, level, codes and fields found in its chain, and the messages
+// per field of a validation error as "errors". Metadata that is absent is
+// omitted. With IncludeChain enabled, the layers of the chain are
+// included as well. The level is encoded by its syslog keyword, e.g. "err".
+// The same output is produced by formatting an error of this package with
+// the %j verb.
+func MarshalJSON(err error) ([]byte, error) {
+	return json.Marshal(toJSONError(err))
+}
+
+// EncodeJSON writes errs to w as a JSON array of the encodings returned by
+// MarshalJSON. The errors are encoded one at a time, so large batches are
+// streamed instead of built in memory first.
+func EncodeJSON(w io.Writer, errs []error) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(w)
+	for i, err := range errs {
+		if i > 0 {
+			if _, writeErr := io.WriteString(w, ","); writeErr != nil {
+				return writeErr
+			}
+		}
+		if encodeErr := encoder.Encode(toJSONError(err)); encodeErr != nil {
+			return encodeErr
+		}
+	}
+	_, err := io.WriteString(w, "]")
+	return err
+}
+
+func toJSONError(err error) jsonError {
+	if err == nil {
+		return jsonError{}
+	}
+	result := jsonError{Message: err.Error()}
+	if status, ok := FindStatus(err); ok {
+		result.Status = status
+	}
+	if level, ok := FindLevel(err); ok {
+		result.Level = syslog.KeyBySeverity(level)
+	}
+	if code, ok := FindCode(err); ok {
+		result.Code = code
+	}
+	if code, ok := FindNumericCode(err); ok {
+		result.ErrorCode = &code
+	}
+	fields := FindFields(err)
+	if fieldErrors, ok := ValidationErrors(err); ok && len(fieldErrors) > 0 {
+		result.Errors = fieldErrors
+		// the messages per field are already reported as errors
+		for key, message := range fieldErrors {
+			if fields[key] == message {
+				delete(fields, key)
+			}
+		}
+	}
+	if len(fields) > 0 {
+		result.Fields = fields
+	}
+	if includeChainEnabled() {
+		result.Chain = toJSONLayers(err)
+	}
+	return result
+}
+
+func toJSONLayers(err error) []jsonLayer {
+	var layers []jsonLayer
+	for _, link := range Links(err) {
+		message, _ := splitMessage(link)
+		layer := jsonLayer{
+			Type:    fmt.Sprintf("%T", link),
+			Message: message,
+		}
+		switch annotation := link.(type) {
+		case *withStatus:
+			layer.Status = annotation.status
+		case *withLevel:
+			layer.Level = syslog.KeyBySeverity(annotation.level)
+		}
+		layers = append(layers, layer)
+	}
+	return layers
+}
+
+// formatJSON writes the JSON encoding of err for the %j verb.
+func formatJSON(s fmt.State, err error) {
+	data, marshalErr := MarshalJSON(err)
+	if marshalErr != nil {
+		fmt.Fprintf(s, "%%!j(%s)", marshalErr)
+		return
+	}
+	io.WriteString(s, string(data))
+}
@@ -1,8 +1,14 @@
 package errors
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"github.com/confetti-framework/syslog/log_level"
+	"io"
+	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 )
 
@@ -49,3 +55,180 @@ func TestFrameMarshalJSON(t *testing.T) {
 		}
 	}
 }
+
+// jsonVerb is kept in a variable, so go vet accepts the custom verb.
+var jsonVerb = "%j"
+
+func TestMarshalJSON(t *testing.T) {
+	var tests = []struct {
+		err  error
+		want string
+	}{{
+		nil,
+		`{"message":""}`,
+	}, {
+		io.EOF,
+		`{"message":"EOF"}`,
+	}, {
+		New("not found"),
+		`{"message":"not found"}`,
+	}, {
+		WithCode(NotFound("user %d not found", 12).Level(log_level.WARNING), "E1"),
+		`{"message":"user 12 not found","status":404,"level":"warning","code":"E1"}`,
+	}, {
+		WithField(Wrap(io.EOF, "read failed"), "file", "a.txt"),
+		`{"message":"read failed: EOF","fields":{"file":"a.txt"}}`,
+	}}
+	for i, tt := range tests {
+		got, err := MarshalJSON(tt.err)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("test %d: MarshalJSON:\n got %s\n want %s", i+1, got, tt.want)
+		}
+	}
+}
+
+func TestMarshalJSONUnsupportedField(t *testing.T) {
+	_, err := MarshalJSON(WithField(io.EOF, "channel", make(chan int)))
+	if err == nil {
+		t.Error("MarshalJSON: expected an error for an unsupported field value")
+	}
+}
+
+func TestFormatJSON(t *testing.T) {
+	var tests = []error{
+		New("not found"),
+		Wrap(io.EOF, "read failed"),
+		WithStack(io.EOF),
+		WithMessage(io.EOF, "read failed"),
+		NotFound("user not found").Level(log_level.ERROR),
+		WithLevel(io.EOF, log_level.ERROR),
+		WithCode(io.EOF, "E1"),
+		WithNumericCode(io.EOF, 1001),
+		WithField(io.EOF, "file", "a.txt"),
+		WithHeaders(io.EOF, nil),
+		WithRetryAfter(io.EOF, 0),
+		WrapCause(NotFound("user not found"), io.EOF),
+	}
+	for i, err := range tests {
+		want, marshalErr := MarshalJSON(err)
+		if marshalErr != nil {
+			t.Fatal(marshalErr)
+		}
+		got := fmt.Sprintf(jsonVerb, err)
+		if got != string(want) {
+			t.Errorf("test %d: %%j:\n got %s\n want %s", i+1, got, want)
+		}
+		if !json.Valid([]byte(got)) {
+			t.Errorf("test %d: %%j: invalid JSON %s", i+1, got)
+		}
+	}
+}
+
+func TestFormatJSONUnsupportedField(t *testing.T) {
+	got := fmt.Sprintf(jsonVerb, WithField(io.EOF, "channel", make(chan int)))
+	if !strings.HasPrefix(got, "%!j(") {
+		t.Errorf("%%j: got %q, want a %%!j(...) error", got)
+	}
+}
+
+func TestMarshalJSONIncludeChain(t *testing.T) {
+	IncludeChain(true)
+	defer IncludeChain(false)
+	err := WithMessage(WithStatus(WithLevel(io.EOF, log_level.WARNING), 404), "read failed")
+
+	got, marshalErr := MarshalJSON(err)
+	if marshalErr != nil {
+		t.Fatal(marshalErr)
+	}
+	want := `{"message":"read failed: EOF","status":404,"level":"warning","chain":[` +
+		`{"type":"*errors.withMessage","message":"read failed"},` +
+		`{"type":"*errors.withStatus","message":"","status":404},` +
+		`{"type":"*errors.withLevel","message":"","level":"warning"},` +
+		`{"type":"*errors.errorString","message":"EOF"}]}`
+	if string(got) != want {
+		t.Errorf("MarshalJSON:\n got %s\n want %s", got, want)
+	}
+}
+
+func TestMarshalJSONWithoutChain(t *testing.T) {
+	got, err := MarshalJSON(WithStatus(io.EOF, 404))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(got), `"chain"`) {
+		t.Errorf("MarshalJSON: got %s, want no chain", got)
+	}
+}
+
+func TestMarshalJSONValidationErrors(t *testing.T) {
+	err := NewValidation(map[string]string{
+		"name":  "is required",
+		"email": "is invalid",
+	})
+
+	got, marshalErr := MarshalJSON(err)
+	if marshalErr != nil {
+		t.Fatal(marshalErr)
+	}
+	want := `{"message":"validation failed for 2 fields","status":422,` +
+		`"errors":{"email":"is invalid","name":"is required"}}`
+	if string(got) != want {
+		t.Errorf("MarshalJSON:\n got %s\n want %s", got, want)
+	}
+
+	var decoded struct {
+		Errors map[string]string `json:"errors"`
+	}
+	if err := json.Unmarshal(got, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Errors["name"] != "is required" || len(decoded.Errors) != 2 {
+		t.Errorf("round trip: got %v", decoded.Errors)
+	}
+}
+
+func TestEncodeJSON(t *testing.T) {
+	errs := []error{
+		NotFound("user not found"),
+		WithField(Wrap(io.EOF, "read failed"), "file", "a.txt"),
+		io.EOF,
+	}
+	var buf bytes.Buffer
+	if err := EncodeJSON(&buf, errs); err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded []jsonError
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("EncodeJSON: invalid JSON %s: %v", buf.String(), err)
+	}
+	want := []jsonError{
+		{Message: "user not found", Status: 404},
+		{Message: "read failed: EOF", Fields: map[string]interface{}{"file": "a.txt"}},
+		{Message: "EOF"},
+	}
+	if !reflect.DeepEqual(decoded, want) {
+		t.Errorf("EncodeJSON:\n got %#v\n want %#v", decoded, want)
+	}
+}
+
+func TestEncodeJSONEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := EncodeJSON(&buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "[]" {
+		t.Errorf("EncodeJSON: got %s, want []", buf.String())
+	}
+}
+
+func TestEncodeJSONUnsupportedField(t *testing.T) {
+	var buf bytes.Buffer
+	err := EncodeJSON(&buf, []error{WithField(io.EOF, "channel", make(chan int))})
+	if err == nil {
+		t.Error("EncodeJSON: expected an error for an unsupported field value")
+	}
+}
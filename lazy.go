@@ -0,0 +1,94 @@
+package errors
+
+import (
+	"fmt"
+	syslog "github.com/confetti-framework/syslog/log_level"
+	"io"
+	"sync"
+)
+
+// WrapLazy returns an error annotating err with a stack trace at the point
+// WrapLazy is called, and a message that is computed by fn. fn is only
+// called when the message is needed, e.g. when Error() is invoked, and its
+// result is cached. Use it in hot paths where building the message is
+// expensive and the error is often discarded. If err is nil, WrapLazy
+// returns nil.
+func WrapLazy(err error, fn func() string) error {
+	if err == nil {
+		return nil
+	}
+	return &withStack{
+		&withLazyMessage{cause: err, fn: fn},
+		callers(),
+	}
+}
+
+type withLazyMessage struct {
+	cause error
+	fn    func() string
+	once  sync.Once
+	msg   string
+}
+
+// message calls fn the first time it is needed and returns the cached
+// result afterwards.
+func (w *withLazyMessage) message() string {
+	w.once.Do(func() {
+		if w.fn != nil {
+			w.msg = w.fn()
+		}
+	})
+	return w.msg
+}
+
+func (w *withLazyMessage) Error() string {
+	msg := w.message()
+	if w.cause.Error() == "" {
+		return truncateMessage(msg)
+	}
+	return truncateMessage(msg + ": " + w.cause.Error())
+}
+
+func (w *withLazyMessage) Format(st fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if st.Flag('+') {
+			fmt.Fprintf(st, "%+v\n", w.cause)
+			io.WriteString(st, w.message())
+			return
+		}
+		fallthrough
+	case 's', 'q':
+		io.WriteString(st, w.Error())
+	case 'j':
+		formatJSON(st, w)
+	}
+}
+
+func (w *withLazyMessage) Unwrap() error {
+	return w.cause
+}
+
+func (w *withLazyMessage) Cause() error {
+	return Unwrap(w)
+}
+
+func (w *withLazyMessage) Wrap(message string, args ...interface{}) *withMessage {
+	return newMessage(w, message, args...)
+}
+
+func (w *withLazyMessage) Level(level syslog.Level) *withLevel {
+	return withLevelAt(w, level, 1)
+}
+
+func (w *withLazyMessage) Status(status int) *withStatus {
+	return WithStatus(w, status)
+}
+
+func (w *withLazyMessage) Alert() *withAlert {
+	return &withAlert{w}
+}
+
+func (w *withLazyMessage) With(key string, value interface{}) *withField {
+	return &withField{cause: w, key: key, value: value}
+}
@@ -0,0 +1,37 @@
+package errors
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func Test_wrap_lazy_nil(t *testing.T) {
+	assert.Nil(t, WrapLazy(nil, func() string { return "read" }))
+}
+
+func Test_wrap_lazy_only_evaluates_on_error(t *testing.T) {
+	calls := 0
+	err := WrapLazy(io.EOF, func() string {
+		calls++
+		return fmt.Sprintf("read %d bytes", 512)
+	})
+
+	assert.Equal(t, 0, calls)
+	assert.True(t, Is(err, io.EOF))
+	assert.Equal(t, 0, calls)
+
+	assert.Equal(t, "read 512 bytes: EOF", err.Error())
+	assert.Equal(t, "read 512 bytes: EOF", err.Error())
+	assert.Equal(t, "read 512 bytes: EOF", fmt.Sprintf("%s", err))
+	assert.Equal(t, 1, calls)
+}
+
+func Test_wrap_lazy_has_stack(t *testing.T) {
+	err := WrapLazy(io.EOF, func() string { return "read" })
+
+	_, ok := FindStack(err)
+	assert.True(t, ok)
+	assert.Contains(t, fmt.Sprintf("%+v", err), "read")
+}
@@ -0,0 +1,56 @@
+package errors
+
+import (
+	syslog "github.com/confetti-framework/syslog/log_level"
+	"sync"
+)
+
+// DefaultLogLevel is the level LogWrap logs errors without a level at.
+const DefaultLogLevel = syslog.ERROR
+
+// Logger is the minimal interface LogWrap needs to log an error. It is
+// easily implemented on top of any logging library.
+type Logger interface {
+	Log(level syslog.Level, message string)
+}
+
+var (
+	loggerMu      sync.RWMutex
+	packageLogger Logger
+)
+
+// SetLogger configures the Logger this package reports its own warnings
+// to, such as the use of a sentinel marked with Deprecate. A nil logger,
+// the default, discards them.
+func SetLogger(logger Logger) {
+	loggerMu.Lock()
+	defer loggerMu.Unlock()
+	packageLogger = logger
+}
+
+func currentLogger() Logger {
+	loggerMu.RLock()
+	defer loggerMu.RUnlock()
+	return packageLogger
+}
+
+// LogWrap wraps err like Wrap, logs the wrapped error at its level (or
+// DefaultLogLevel without one) and returns it for further propagation,
+// marked with WithLogged. An error that is already marked as logged is
+// wrapped but not logged again. If err is nil, LogWrap logs nothing and
+// returns nil. A nil logger only wraps.
+func LogWrap(logger Logger, err error, message string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	wrapped := newWrap(err, callers(), message, args...)
+	if logger != nil && !IsLogged(wrapped) {
+		level, ok := FindLevel(wrapped)
+		if !ok {
+			level = DefaultLogLevel
+		}
+		logger.Log(level, wrapped.Error())
+		return WithLogged(wrapped)
+	}
+	return wrapped
+}
@@ -0,0 +1,64 @@
+package errors
+
+import (
+	"github.com/confetti-framework/syslog/log_level"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+type logEntry struct {
+	level   log_level.Level
+	message string
+}
+
+type fakeLogger struct {
+	entries []logEntry
+}
+
+func (l *fakeLogger) Log(level log_level.Level, message string) {
+	l.entries = append(l.entries, logEntry{level, message})
+}
+
+func Test_log_wrap_nil(t *testing.T) {
+	logger := &fakeLogger{}
+
+	assert.Nil(t, LogWrap(logger, nil, "read failed"))
+	assert.Empty(t, logger.entries)
+}
+
+func Test_log_wrap_logs_at_error_level(t *testing.T) {
+	logger := &fakeLogger{}
+
+	err := LogWrap(logger, WithLevel(io.EOF, log_level.WARNING), "read %s failed", "config")
+
+	assert.Equal(t, "read config failed: EOF", err.Error())
+	assert.Equal(t, []logEntry{{log_level.WARNING, "read config failed: EOF"}}, logger.entries)
+	assert.True(t, Is(err, io.EOF))
+}
+
+func Test_log_wrap_default_level(t *testing.T) {
+	logger := &fakeLogger{}
+
+	LogWrap(logger, io.EOF, "read failed")
+
+	assert.Equal(t, []logEntry{{log_level.ERROR, "read failed: EOF"}}, logger.entries)
+}
+
+func Test_log_wrap_stack(t *testing.T) {
+	stack, ok := FindStack(LogWrap(nil, io.EOF, "read failed"))
+
+	assert.True(t, ok)
+	assert.Equal(t, "Test_log_wrap_stack", funcname(stack[0].name()))
+}
+
+func Test_log_wrap_logs_once(t *testing.T) {
+	logger := &fakeLogger{}
+
+	err := LogWrap(logger, io.EOF, "read failed")
+	err = LogWrap(logger, err, "load config")
+
+	assert.True(t, IsLogged(err))
+	assert.Equal(t, "load config: read failed: EOF", err.Error())
+	assert.Equal(t, []logEntry{{log_level.ERROR, "read failed: EOF"}}, logger.entries)
+}
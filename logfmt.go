@@ -0,0 +1,78 @@
+package errors
+
+import (
+	"fmt"
+	"github.com/confetti-framework/syslog"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Logfmt returns err encoded as logfmt, e.g.
+//
+//	msg="user not found" level=err status=404 code=E1 user_id=12
+//
+// The level is encoded by its syslog keyword. Status, level and code are
+// omitted when absent, and the fields of err follow in alphabetical order.
+// Values containing spaces, quotes or equal signs are quoted. Keys cannot
+// be quoted in logfmt, so such characters in field keys are replaced by an
+// underscore, and a field named msg, level, status or code is written as
+// field.msg, field.level and so on, to not clash with the keys above.
+func Logfmt(err error) string {
+	if err == nil {
+		return ""
+	}
+	var b strings.Builder
+	writeLogfmtPair(&b, "msg", err.Error())
+	if level, ok := FindLevel(err); ok {
+		writeLogfmtPair(&b, "level", syslog.KeyBySeverity(level))
+	}
+	if status, ok := FindStatus(err); ok {
+		writeLogfmtPair(&b, "status", strconv.Itoa(status))
+	}
+	if code, ok := FindCode(err); ok {
+		writeLogfmtPair(&b, "code", code)
+	}
+	fields := FindFields(err)
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		writeLogfmtPair(&b, logfmtKey(key), fmt.Sprint(fields[key]))
+	}
+	return b.String()
+}
+
+// logfmtReservedKeys are the keys Logfmt writes itself.
+var logfmtReservedKeys = map[string]bool{"msg": true, "level": true, "status": true, "code": true}
+
+// logfmtKey makes the key of a field safe to write unquoted.
+func logfmtKey(key string) string {
+	if logfmtReservedKeys[key] {
+		return "field." + key
+	}
+	if key == "" {
+		return "_"
+	}
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' || r == '\\' || !unicode.IsPrint(r) {
+			return '_'
+		}
+		return r
+	}, key)
+}
+
+func writeLogfmtPair(b *strings.Builder, key, value string) {
+	if b.Len() > 0 {
+		b.WriteByte(' ')
+	}
+	b.WriteString(key)
+	b.WriteByte('=')
+	if value == "" || strings.ContainsAny(value, " =\"\t\r\n\\") {
+		value = strconv.Quote(value)
+	}
+	b.WriteString(value)
+}
@@ -0,0 +1,49 @@
+package errors
+
+import (
+	"github.com/confetti-framework/syslog/log_level"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func Test_logfmt_nil(t *testing.T) {
+	assert.Equal(t, "", Logfmt(nil))
+}
+
+func Test_logfmt_message_only(t *testing.T) {
+	assert.Equal(t, "msg=EOF", Logfmt(io.EOF))
+	assert.Equal(t, `msg="read failed: EOF"`, Logfmt(Wrap(io.EOF, "read failed")))
+}
+
+func Test_logfmt_all_fields(t *testing.T) {
+	err := WithCode(NotFound("user not found").Level(log_level.ERROR), "E1")
+
+	assert.Equal(t, `msg="user not found" level=err status=404 code=E1`, Logfmt(err))
+}
+
+func Test_logfmt_error_fields(t *testing.T) {
+	err := WithField(WithField(New("user not found"), "user_id", 12), "account", "acme corp")
+
+	assert.Equal(t, `msg="user not found" account="acme corp" user_id=12`, Logfmt(err))
+}
+
+func Test_logfmt_quoting(t *testing.T) {
+	assert.Equal(t, `msg="say \"hi\""`, Logfmt(New(`say "hi"`)))
+	assert.Equal(t, `msg="a=b"`, Logfmt(New("a=b")))
+	assert.Equal(t, `msg=""`, Logfmt(New("")))
+	assert.Equal(t, `msg="line\nbreak"`, Logfmt(New("line\nbreak")))
+}
+
+func Test_logfmt_field_keys(t *testing.T) {
+	err := WithField(WithField(New("user not found"), "user id", 12), "a=b", "c")
+
+	assert.Equal(t, `msg="user not found" a_b=c user_id=12`, Logfmt(err))
+	assert.Equal(t, `msg="user not found" _=12`, Logfmt(WithField(New("user not found"), "", 12)))
+}
+
+func Test_logfmt_reserved_field_keys(t *testing.T) {
+	err := WithField(WithField(WithCode(New("user not found"), "E1"), "code", "E2"), "msg", "hi")
+
+	assert.Equal(t, `msg="user not found" code=E1 field.code=E2 field.msg=hi`, Logfmt(err))
+}
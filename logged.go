@@ -0,0 +1,62 @@
+package errors
+
+import (
+	"fmt"
+	syslog "github.com/confetti-framework/syslog/log_level"
+)
+
+// WithLogged marks err as logged, so layers further up can skip logging it
+// again. If err is nil, WithLogged returns nil.
+func WithLogged(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &withLogged{err}
+}
+
+// IsLogged reports whether any layer in the chain of err is marked with
+// WithLogged.
+func IsLogged(err error) bool {
+	var loggedHolder *withLogged
+	return As(err, &loggedHolder)
+}
+
+type withLogged struct {
+	cause error
+}
+
+func (w *withLogged) Error() string {
+	return w.cause.Error()
+}
+
+func (w *withLogged) Format(st fmt.State, verb rune) {
+	formatCause(st, verb, w, w.cause)
+}
+
+func (w *withLogged) Unwrap() error {
+	return w.cause
+}
+
+func (w *withLogged) Cause() error {
+	return Unwrap(w)
+}
+
+func (w *withLogged) Wrap(message string, args ...interface{}) *withMessage {
+	return newMessage(w, message, args...)
+}
+
+func (w *withLogged) Level(level syslog.Level) *withLevel {
+	return withLevelAt(w, level, 1)
+}
+
+func (w *withLogged) Status(status int) *withStatus {
+	return WithStatus(w, status)
+}
+
+func (w *withLogged) Alert() *withAlert {
+	return &withAlert{w}
+}
+
+func (w *withLogged) With(key string, value interface{}) *withField {
+	return &withField{cause: w, key: key, value: value}
+}
@@ -0,0 +1,23 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func Test_with_logged_nil(t *testing.T) {
+	assert.Nil(t, WithLogged(nil))
+}
+
+func Test_is_logged_after_wrapping(t *testing.T) {
+	err := WithStatus(Wrap(WithLogged(io.EOF), "read"), 500)
+
+	assert.True(t, IsLogged(err))
+	assert.Equal(t, "read: EOF", err.Error())
+}
+
+func Test_is_logged_without_mark(t *testing.T) {
+	assert.False(t, IsLogged(Wrap(io.EOF, "read")))
+	assert.False(t, IsLogged(nil))
+}
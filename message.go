@@ -0,0 +1,107 @@
+package errors
+
+import (
+	syslog "github.com/confetti-framework/syslog/log_level"
+	"strings"
+	"sync/atomic"
+)
+
+// ellipsis is appended to messages that are truncated.
+const ellipsis = "..."
+
+var maxMessageLength int64
+
+// SetMaxMessageLength limits the length of the messages composed by Wrap and
+// WithMessage. Messages longer than n runes are truncated to their first n
+// runes, followed by "...", so the result is n+3 runes long. This protects
+// logs and responses against messages that keep growing, e.g. when an error
+// is wrapped in a retry loop. The default, 0, means unlimited.
+func SetMaxMessageLength(n int) {
+	atomic.StoreInt64(&maxMessageLength, int64(n))
+}
+
+// truncateMessage applies the limit set with SetMaxMessageLength to message.
+func truncateMessage(message string) string {
+	max := int(atomic.LoadInt64(&maxMessageLength))
+	if max <= 0 || len(message) <= max {
+		return message
+	}
+	runes := []rune(message)
+	if len(runes) <= max {
+		return message
+	}
+	return string(runes[:max]) + ellipsis
+}
+
+// SafeMessage returns the message of err with every % escaped as %%, so the
+// result can be used as a format string without producing artifacts such as
+// "%!d(MISSING)". Formatting the result without arguments yields err.Error().
+func SafeMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	return LiteralMessage(err.Error())
+}
+
+// LiteralMessage returns msg with every % escaped as %%, so a message from
+// user input can safely be part of a format string that is formatted with
+// arguments, e.g. New(LiteralMessage(input)+": %d", id). New and Wrap only
+// format their message when arguments are given, so without arguments the
+// escaped percent signs would show up as %%; use NewLiteral, or pass msg
+// as is, instead.
+func LiteralMessage(msg string) string {
+	return strings.ReplaceAll(msg, "%", "%%")
+}
+
+// FilteredMessage composes the message of err like Error(), but only from
+// the layers whose level is at least as severe as minLevel. The level of a
+// message is that of the nearest WithLevel wrapping it, so in
+//
+//	New("unique constraint on users.email").Level(log_level.DEBUG).
+//		Wrap("cannot register user").Level(log_level.ERROR)
+//
+// only "cannot register user" remains with minLevel ERROR. Messages that are
+// not wrapped by any level are always included.
+func FilteredMessage(err error, minLevel syslog.Level) string {
+	var messages []string
+	levelled := false
+	var level syslog.Level
+	for err != nil {
+		if levelHolder, ok := err.(*withLevel); ok {
+			levelled, level = true, levelHolder.level
+		}
+		message, next := splitMessage(err)
+		if message != "" && (!levelled || level <= minLevel) {
+			messages = append(messages, message)
+		}
+		err = next
+	}
+	return strings.Join(messages, ": ")
+}
+
+// splitMessage returns the part of the message that err adds to the message
+// of the error it wraps, and that wrapped error. If the message of err does
+// not end with the message of its cause, the full message is returned without
+// a cause.
+func splitMessage(err error) (message string, next error) {
+	switch layer := err.(type) {
+	case *withMessage:
+		return layer.msg, layer.cause
+	case *withCause:
+		return layer.outer.Error(), layer.cause
+	case *withBecause:
+		return layer.outer.msg, layer.cause
+	case interface{ Unwrap() error }:
+		message, next = err.Error(), layer.Unwrap()
+		if next == nil {
+			return message, nil
+		}
+		own := strings.TrimSuffix(message, next.Error())
+		if own == message {
+			return message, nil
+		}
+		return strings.TrimSuffix(own, ": "), next
+	default:
+		return err.Error(), nil
+	}
+}
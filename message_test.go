@@ -0,0 +1,126 @@
+package errors
+
+import (
+	"fmt"
+	"github.com/confetti-framework/syslog/log_level"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+)
+
+func Test_safe_message_nil(t *testing.T) {
+	assert.Equal(t, "", SafeMessage(nil))
+}
+
+func Test_safe_message_without_percent(t *testing.T) {
+	assert.Equal(t, "user not found", SafeMessage(New("user not found")))
+}
+
+func Test_safe_message_escapes_percent(t *testing.T) {
+	err := Wrap(New("rate %d"), "limit at 100%")
+
+	assert.Equal(t, "limit at 100%%: rate %%d", SafeMessage(err))
+}
+
+func Test_safe_message_survives_formatting(t *testing.T) {
+	err := Wrap(New("rate %d exceeded by %s"), "50% used")
+
+	assert.Equal(t, err.Error(), fmt.Sprintf(SafeMessage(err)))
+}
+
+func Test_literal_message(t *testing.T) {
+	assert.Equal(t, "user not found", LiteralMessage("user not found"))
+	assert.Equal(t, "100%% used", LiteralMessage("100% used"))
+	assert.Equal(t, "100%%%% used", LiteralMessage("100%% used"))
+}
+
+func Test_literal_message_survives_formatting(t *testing.T) {
+	for _, msg := range []string{"100% used", "100%% used", "rate %d by %s"} {
+		assert.Equal(t, msg+": 42", New(LiteralMessage(msg)+": %d", 42).Error())
+		assert.Equal(t, msg, fmt.Sprintf(LiteralMessage(msg)))
+	}
+}
+
+func Test_literal_message_without_arguments(t *testing.T) {
+	assert.Equal(t, "50%% off", New(LiteralMessage("50% off")).Error())
+	assert.Equal(t, "50%% off: EOF", Wrap(io.EOF, LiteralMessage("50% off")).Error())
+	assert.Equal(t, "50% off", NewLiteral("50% off").Error())
+}
+
+func Test_new_literal(t *testing.T) {
+	assert.Equal(t, "100% used", NewLiteral("100% used").Error())
+	assert.Equal(t, "100%% used", NewLiteral("100%% used").Error())
+	assert.Equal(t, "rate %d", NewLiteral("rate %d").Error())
+
+	_, ok := FindStack(NewLiteral("100% used"))
+	assert.True(t, ok)
+}
+
+func Test_max_message_length_unlimited(t *testing.T) {
+	SetMaxMessageLength(0)
+
+	err := Wrap(New(strings.Repeat("a", 1000)), "retry")
+	assert.Len(t, err.Error(), 1007)
+}
+
+func Test_max_message_length_truncates(t *testing.T) {
+	SetMaxMessageLength(20)
+	defer SetMaxMessageLength(0)
+
+	var err error = New("connection refused")
+	for i := 0; i < 10; i++ {
+		err = Wrap(err, "attempt %d", i)
+	}
+
+	assert.Equal(t, "attempt 9: attempt 8...", err.Error())
+}
+
+func Test_max_message_length_short_message(t *testing.T) {
+	SetMaxMessageLength(20)
+	defer SetMaxMessageLength(0)
+
+	assert.Equal(t, "retry: EOF", Wrap(io.EOF, "retry").Error())
+}
+
+func Test_max_message_length_counts_characters(t *testing.T) {
+	SetMaxMessageLength(4)
+	defer SetMaxMessageLength(0)
+
+	assert.Equal(t, "fout...", WithMessage(nil, "fouté").Error())
+	assert.Equal(t, "föut", WithMessage(nil, "föut").Error())
+}
+
+func Test_filtered_message_nil(t *testing.T) {
+	assert.Equal(t, "", FilteredMessage(nil, log_level.ERROR))
+}
+
+func Test_filtered_message_mixed_levels(t *testing.T) {
+	err := New("unique constraint on users.email").Level(log_level.DEBUG).
+		Wrap("cannot register user").Level(log_level.ERROR)
+
+	assert.Equal(t, "cannot register user", FilteredMessage(err, log_level.ERROR))
+	assert.Equal(t, "cannot register user: unique constraint on users.email", FilteredMessage(err, log_level.DEBUG))
+	assert.Equal(t, "", FilteredMessage(err, log_level.EMERGENCY))
+}
+
+func Test_filtered_message_unlevelled_layers(t *testing.T) {
+	err := Wrap(WithLevel(Wrap(io.EOF, "read row"), log_level.DEBUG), "handle request")
+
+	assert.Equal(t, "handle request", FilteredMessage(err, log_level.WARNING))
+	assert.Equal(t, "EOF", FilteredMessage(io.EOF, log_level.EMERGENCY))
+}
+
+func Test_filtered_message_foreign_wrappers(t *testing.T) {
+	inner := WithLevel(fmt.Errorf("query users: %w", io.EOF), log_level.DEBUG)
+	err := WithLevel(fmt.Errorf("load user: %w", inner), log_level.ERROR)
+
+	assert.Equal(t, "load user", FilteredMessage(err, log_level.ERROR))
+	assert.Equal(t, err.Error(), FilteredMessage(err, log_level.DEBUG))
+}
+
+func Test_filtered_message_keeps_metadata_layers(t *testing.T) {
+	err := WithStatus(WithCode(Wrap(io.EOF, "read failed"), "E1"), 500)
+
+	assert.Equal(t, err.Error(), FilteredMessage(err, log_level.EMERGENCY))
+}
@@ -0,0 +1,55 @@
+package errors
+
+import (
+	"strings"
+)
+
+// maxMetricLabelLength limits the length of a label derived from a message.
+const maxMetricLabelLength = 64
+
+// MetricLabel returns a short, low-cardinality label for err that is safe to
+// use as a metrics label. The code of the error is preferred. Without a code,
+// the message of the root cause is lowercased, stripped of digits and
+// punctuation (which usually hold the dynamic values) and truncated. An
+// error whose chain ends in a nil cause gets the label "unknown".
+func MetricLabel(err error) string {
+	if err == nil {
+		return ""
+	}
+	if code, ok := FindCode(err); ok && code != "" {
+		return code
+	}
+	root := Unwrap(err)
+	if root == nil {
+		return "unknown"
+	}
+	return sanitizeLabel(root.Error())
+}
+
+// sanitizeLabel keeps the lowercase letters of message and replaces every
+// other run of characters with a single underscore.
+func sanitizeLabel(message string) string {
+	var b strings.Builder
+	pending := false
+	for _, r := range strings.ToLower(message) {
+		if r < 'a' || r > 'z' {
+			pending = b.Len() > 0
+			continue
+		}
+		if pending {
+			if b.Len()+1 >= maxMetricLabelLength {
+				break
+			}
+			b.WriteByte('_')
+			pending = false
+		}
+		if b.Len() >= maxMetricLabelLength {
+			break
+		}
+		b.WriteRune(r)
+	}
+	if b.Len() == 0 {
+		return "unknown"
+	}
+	return b.String()
+}
@@ -0,0 +1,45 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+)
+
+func Test_metric_label_nil(t *testing.T) {
+	assert.Equal(t, "", MetricLabel(nil))
+}
+
+func Test_metric_label_nil_root(t *testing.T) {
+	assert.Equal(t, "unknown", MetricLabel(WithMessage(nil, "user 12 not found")))
+}
+
+func Test_metric_label_prefers_code(t *testing.T) {
+	first := WithCode(New("user %d not found", 12), "user_not_found")
+	second := WithCode(New("user %d not found", 34), "user_not_found")
+
+	assert.Equal(t, "user_not_found", MetricLabel(first))
+	assert.Equal(t, MetricLabel(first), MetricLabel(second))
+}
+
+func Test_metric_label_from_root_message(t *testing.T) {
+	err := Wrap(New("Timeout after %ds", 3), "request %d failed", 42)
+
+	assert.Equal(t, "timeout_after_s", MetricLabel(err))
+}
+
+func Test_metric_label_from_foreign_error(t *testing.T) {
+	assert.Equal(t, "eof", MetricLabel(Wrap(io.EOF, "read failed")))
+}
+
+func Test_metric_label_without_letters(t *testing.T) {
+	assert.Equal(t, "unknown", MetricLabel(New("404")))
+}
+
+func Test_metric_label_truncated(t *testing.T) {
+	label := MetricLabel(New(strings.Repeat("abc ", 100)))
+
+	assert.True(t, len(label) <= maxMetricLabelLength)
+	assert.False(t, strings.HasSuffix(label, "_"))
+}
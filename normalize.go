@@ -0,0 +1,50 @@
+package errors
+
+import (
+	syslog "github.com/confetti-framework/syslog/log_level"
+	net "net/http"
+	"os"
+)
+
+// DefaultNormalizeLevel is the level Normalize sets on errors without one.
+const DefaultNormalizeLevel = syslog.ERROR
+
+// normalizedStatuses maps well-known standard library errors to statuses.
+var normalizedStatuses = []statusEntry{
+	{os.ErrNotExist, net.StatusNotFound},
+	{os.ErrPermission, net.StatusForbidden},
+}
+
+// Normalize gives an error from any source the shape of the errors of this
+// package, which is useful at the boundary with third-party libraries:
+//
+//   - a stack trace is recorded if the chain has none,
+//   - a status is set if the chain has none and it matches a well-known
+//     error (os.ErrNotExist is 404, os.ErrPermission is 403),
+//   - DefaultNormalizeLevel is set if the chain has no level.
+//
+// Metadata that is already present is left untouched. If err is nil,
+// Normalize returns nil.
+func Normalize(err error) error {
+	if err == nil {
+		return nil
+	}
+	if !hasStack(err) {
+		err = &withStack{
+			err,
+			callers(),
+		}
+	}
+	if _, ok := FindStatus(err); !ok {
+		for _, entry := range normalizedStatuses {
+			if is(err, entry.sentinel) {
+				err = WithStatus(err, entry.status)
+				break
+			}
+		}
+	}
+	if _, ok := FindLevel(err); !ok {
+		err = WithLevel(err, DefaultNormalizeLevel)
+	}
+	return err
+}
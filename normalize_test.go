@@ -0,0 +1,62 @@
+package errors
+
+import (
+	"github.com/confetti-framework/syslog/log_level"
+	"github.com/stretchr/testify/assert"
+	"io"
+	net "net/http"
+	"os"
+	"testing"
+)
+
+func Test_normalize_nil(t *testing.T) {
+	assert.Nil(t, Normalize(nil))
+}
+
+func Test_normalize_not_exist(t *testing.T) {
+	_, openErr := os.Open("/does/not/exist")
+
+	err := Normalize(openErr)
+
+	status, ok := FindStatus(err)
+	assert.True(t, ok)
+	assert.Equal(t, net.StatusNotFound, status)
+	assert.Equal(t, openErr.Error(), err.Error())
+	assert.True(t, Is(err, os.ErrNotExist))
+}
+
+func Test_normalize_permission(t *testing.T) {
+	status, ok := FindStatus(Normalize(os.ErrPermission))
+	assert.True(t, ok)
+	assert.Equal(t, net.StatusForbidden, status)
+}
+
+func Test_normalize_generic_error(t *testing.T) {
+	err := Normalize(io.EOF)
+
+	stack, ok := FindStack(err)
+	assert.True(t, ok)
+	assert.Equal(t, "Test_normalize_generic_error", funcname(stack[0].name()))
+
+	_, ok = FindStatus(err)
+	assert.False(t, ok)
+
+	level, ok := FindLevel(err)
+	assert.True(t, ok)
+	assert.Equal(t, log_level.ERROR, level)
+}
+
+func Test_normalize_keeps_metadata(t *testing.T) {
+	root := New("missing config")
+	inner := root.Status(net.StatusServiceUnavailable).Level(log_level.CRITICAL)
+	err := Normalize(WrapCause(inner, os.ErrNotExist))
+
+	status, _ := FindStatus(err)
+	assert.Equal(t, net.StatusServiceUnavailable, status)
+
+	level, _ := FindLevel(err)
+	assert.Equal(t, log_level.CRITICAL, level)
+
+	stack, _ := FindStack(err)
+	assert.Equal(t, root.StackTrace(), stack)
+}
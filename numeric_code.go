@@ -0,0 +1,71 @@
+package errors
+
+import (
+	"fmt"
+	syslog "github.com/confetti-framework/syslog/log_level"
+)
+
+// WithNumericCode annotates err with an integer application error code, for
+// clients that expect numeric codes rather than the string codes of
+// WithCode. If err is nil, WithNumericCode returns nil.
+func WithNumericCode(err error, code int) error {
+	if err == nil {
+		return nil
+	}
+	return &withNumericCode{
+		err,
+		code,
+	}
+}
+
+// FindNumericCode returns the outermost numeric code in the chain of err.
+func FindNumericCode(err error) (int, bool) {
+	var codeHolder *withNumericCode
+
+	if !As(err, &codeHolder) {
+		return 0, false
+	}
+
+	return codeHolder.code, true
+}
+
+type withNumericCode struct {
+	cause error
+	code  int
+}
+
+func (w *withNumericCode) Error() string {
+	return w.cause.Error()
+}
+
+func (w *withNumericCode) Format(st fmt.State, verb rune) {
+	formatCause(st, verb, w, w.cause)
+}
+
+func (w *withNumericCode) Unwrap() error {
+	return w.cause
+}
+
+func (w *withNumericCode) Cause() error {
+	return Unwrap(w)
+}
+
+func (w *withNumericCode) Wrap(message string, args ...interface{}) *withMessage {
+	return newMessage(w, message, args...)
+}
+
+func (w *withNumericCode) Level(level syslog.Level) *withLevel {
+	return withLevelAt(w, level, 1)
+}
+
+func (w *withNumericCode) Status(status int) *withStatus {
+	return WithStatus(w, status)
+}
+
+func (w *withNumericCode) Alert() *withAlert {
+	return &withAlert{w}
+}
+
+func (w *withNumericCode) With(key string, value interface{}) *withField {
+	return &withField{cause: w, key: key, value: value}
+}
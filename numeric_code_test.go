@@ -0,0 +1,40 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func Test_with_numeric_code_nil(t *testing.T) {
+	assert.Nil(t, WithNumericCode(nil, 1001))
+}
+
+func Test_find_numeric_code(t *testing.T) {
+	err := Wrap(WithNumericCode(io.EOF, 1001), "read")
+
+	code, ok := FindNumericCode(err)
+	assert.True(t, ok)
+	assert.Equal(t, 1001, code)
+	assert.Equal(t, "read: EOF", err.Error())
+}
+
+func Test_find_numeric_code_outermost(t *testing.T) {
+	err := WithNumericCode(WithNumericCode(io.EOF, 1001), 1002)
+
+	code, _ := FindNumericCode(err)
+	assert.Equal(t, 1002, code)
+}
+
+func Test_find_numeric_code_without_code(t *testing.T) {
+	_, ok := FindNumericCode(WithCode(io.EOF, "E1"))
+
+	assert.False(t, ok)
+}
+
+func Test_numeric_code_json(t *testing.T) {
+	data, err := MarshalJSON(WithNumericCode(WithCode(io.EOF, "E1"), 0))
+
+	assert.Nil(t, err)
+	assert.Equal(t, `{"message":"EOF","code":"E1","error_code":0}`, string(data))
+}
@@ -0,0 +1,74 @@
+package errors
+
+import (
+	"fmt"
+	syslog "github.com/confetti-framework/syslog/log_level"
+)
+
+// WithOp annotates err with the name of the operation that failed, such as
+// "LoadUser" or "db.Query". Unlike messages, operation names are not part
+// of Error(); they form a trail that FindOps returns. If err is nil, WithOp
+// returns nil.
+func WithOp(err error, op string) error {
+	if err == nil {
+		return nil
+	}
+	return &withOp{
+		err,
+		op,
+	}
+}
+
+// FindOps returns the operation names in the chain of err, from the
+// outermost to the innermost operation. Joining them with " -> " gives a
+// trail such as "HandleRequest -> LoadUser -> db.Query".
+func FindOps(err error) []string {
+	var ops []string
+	for _, link := range Links(err) {
+		if opHolder, ok := link.(*withOp); ok {
+			ops = append(ops, opHolder.op)
+		}
+	}
+	return ops
+}
+
+type withOp struct {
+	cause error
+	op    string
+}
+
+func (w *withOp) Error() string {
+	return w.cause.Error()
+}
+
+func (w *withOp) Format(st fmt.State, verb rune) {
+	formatCause(st, verb, w, w.cause)
+}
+
+func (w *withOp) Unwrap() error {
+	return w.cause
+}
+
+func (w *withOp) Cause() error {
+	return Unwrap(w)
+}
+
+func (w *withOp) Wrap(message string, args ...interface{}) *withMessage {
+	return newMessage(w, message, args...)
+}
+
+func (w *withOp) Level(level syslog.Level) *withLevel {
+	return withLevelAt(w, level, 1)
+}
+
+func (w *withOp) Status(status int) *withStatus {
+	return WithStatus(w, status)
+}
+
+func (w *withOp) Alert() *withAlert {
+	return &withAlert{w}
+}
+
+func (w *withOp) With(key string, value interface{}) *withField {
+	return &withField{cause: w, key: key, value: value}
+}
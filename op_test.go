@@ -0,0 +1,35 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+)
+
+func Test_with_op_nil(t *testing.T) {
+	assert.Nil(t, WithOp(nil, "LoadUser"))
+}
+
+func Test_with_op_keeps_message(t *testing.T) {
+	err := WithOp(Wrap(io.EOF, "read"), "LoadUser")
+
+	assert.Equal(t, "read: EOF", err.Error())
+	assert.True(t, Is(err, io.EOF))
+}
+
+func Test_find_ops_outer_to_inner(t *testing.T) {
+	err := WithOp(io.EOF, "db.Query")
+	err = Wrap(WithOp(err, "LoadUser"), "load user")
+	err = WithOp(err, "HandleRequest")
+
+	ops := FindOps(err)
+
+	assert.Equal(t, []string{"HandleRequest", "LoadUser", "db.Query"}, ops)
+	assert.Equal(t, "HandleRequest -> LoadUser -> db.Query", strings.Join(ops, " -> "))
+}
+
+func Test_find_ops_without_ops(t *testing.T) {
+	assert.Empty(t, FindOps(Wrap(io.EOF, "read")))
+	assert.Empty(t, FindOps(nil))
+}
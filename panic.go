@@ -0,0 +1,31 @@
+package errors
+
+import (
+	"fmt"
+)
+
+// PanicError returns an error for the value r returned by recover. If r is
+// an error that already has a stack trace, such as an error of this package
+// that was passed to panic, that stack trace is kept and only the message
+// "panic" is added. Otherwise the stack trace is recorded at the point
+// PanicError is called, which includes the frames of the panic. If r is
+// nil, PanicError returns nil.
+//
+//	defer func() {
+//	        if r := recover(); r != nil {
+//	                err = errors.PanicError(r)
+//	        }
+//	}()
+func PanicError(r interface{}) error {
+	if r == nil {
+		return nil
+	}
+	err, ok := r.(error)
+	if !ok {
+		return newFundamental(callers(), fmt.Sprintf("panic: %v", r))
+	}
+	if _, ok := FindStack(err); ok {
+		return WithMessage(err, "panic")
+	}
+	return newWrap(err, callers(), "panic")
+}
@@ -0,0 +1,65 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func recovered(value interface{}) (err error) {
+	defer func() {
+		err = PanicError(recover())
+	}()
+	panicking(value)
+	return nil
+}
+
+func panicking(value interface{}) {
+	panic(value)
+}
+
+func newPanicValue() error {
+	return New("stackful")
+}
+
+func Test_panic_error_nil(t *testing.T) {
+	assert.Nil(t, PanicError(nil))
+}
+
+func Test_panic_error_stackful(t *testing.T) {
+	value := newPanicValue()
+
+	err := recovered(value)
+
+	assert.EqualError(t, err, "panic: stackful")
+	assert.True(t, Is(err, value))
+	stack, ok := FindStack(err)
+	assert.True(t, ok)
+	assert.Equal(t, "newPanicValue", funcname(stack[0].name()))
+}
+
+func Test_panic_error_stackless_error(t *testing.T) {
+	err := recovered(io.EOF)
+
+	assert.EqualError(t, err, "panic: EOF")
+	assert.True(t, Is(err, io.EOF))
+	assertPanicStack(t, err)
+}
+
+func Test_panic_error_value(t *testing.T) {
+	err := recovered(42)
+
+	assert.EqualError(t, err, "panic: 42")
+	assertPanicStack(t, err)
+}
+
+func assertPanicStack(t *testing.T, err error) {
+	stack, ok := FindStack(err)
+	assert.True(t, ok)
+	assert.Equal(t, "recovered.func1", funcname(stack[0].name()))
+	var names []string
+	for _, frame := range stack {
+		names = append(names, funcname(frame.name()))
+	}
+	assert.Contains(t, names, "panicking")
+}
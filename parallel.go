@@ -0,0 +1,24 @@
+package errors
+
+import (
+	"sync"
+)
+
+// Parallel runs every function in its own goroutine and waits for all of
+// them to return. It returns nil if all functions succeed, and otherwise
+// the errors that were returned, joined as with Join in the order of the
+// functions. The errors are returned as is, so each keeps the stack trace
+// of the place it was created.
+func Parallel(fns ...func() error) error {
+	errs := make([]error, len(fns))
+	var wg sync.WaitGroup
+	wg.Add(len(fns))
+	for i, fn := range fns {
+		go func(i int, fn func() error) {
+			defer wg.Done()
+			errs[i] = fn()
+		}(i, fn)
+	}
+	wg.Wait()
+	return Join(errs...)
+}
@@ -0,0 +1,41 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func parallelFailure() error {
+	return New("parallel failure")
+}
+
+func Test_parallel_without_functions(t *testing.T) {
+	assert.Nil(t, Parallel())
+}
+
+func Test_parallel_all_succeed(t *testing.T) {
+	succeed := func() error { return nil }
+
+	assert.Nil(t, Parallel(succeed, succeed))
+}
+
+func Test_parallel_mixed(t *testing.T) {
+	err := Parallel(
+		func() error { return nil },
+		func() error { return Wrap(io.EOF, "read") },
+		func() error { return nil },
+		parallelFailure,
+	)
+
+	assert.EqualError(t, err, "read: EOF\nparallel failure")
+	assert.True(t, Is(err, io.EOF))
+}
+
+func Test_parallel_keeps_stacks(t *testing.T) {
+	err := Parallel(parallelFailure)
+
+	stack, ok := FindStack(err)
+	assert.True(t, ok)
+	assert.Equal(t, "parallelFailure", funcname(stack[0].name()))
+}
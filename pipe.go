@@ -0,0 +1,17 @@
+package errors
+
+// Pipe passes err through transforms in order, each receiving the result of
+// the previous one, and returns the result of the last. It stops as soon as
+// a transform returns nil, and returns nil if err is nil. Transforms such as
+// Normalize compose with it:
+//
+//	err = errors.Pipe(err, errors.Normalize, withService)
+func Pipe(err error, transforms ...func(error) error) error {
+	for _, transform := range transforms {
+		if err == nil {
+			return nil
+		}
+		err = transform(err)
+	}
+	return err
+}
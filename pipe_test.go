@@ -0,0 +1,50 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"testing"
+)
+
+func withService(err error) error {
+	return WithField(err, "service", "users")
+}
+
+func Test_pipe_without_transforms(t *testing.T) {
+	assert.Equal(t, io.EOF, Pipe(io.EOF))
+	assert.Nil(t, Pipe(nil))
+}
+
+func Test_pipe_two_stages(t *testing.T) {
+	err := Pipe(os.ErrNotExist, Normalize, withService)
+
+	status, ok := FindStatus(err)
+	assert.True(t, ok)
+	assert.Equal(t, 404, status)
+	level, ok := FindLevel(err)
+	assert.True(t, ok)
+	assert.Equal(t, DefaultNormalizeLevel, level)
+	assert.Equal(t, "users", FindFields(err)["service"])
+}
+
+func Test_pipe_order(t *testing.T) {
+	first := func(err error) error { return Wrap(err, "first") }
+	second := func(err error) error { return Wrap(err, "second") }
+
+	assert.EqualError(t, Pipe(io.EOF, first, second), "second: first: EOF")
+}
+
+func Test_pipe_short_circuits_on_nil(t *testing.T) {
+	called := false
+	drop := func(error) error { return nil }
+	record := func(err error) error {
+		called = true
+		return err
+	}
+
+	assert.Nil(t, Pipe(io.EOF, drop, record))
+	assert.False(t, called)
+	assert.Nil(t, Pipe(nil, record))
+	assert.False(t, called)
+}
@@ -0,0 +1,46 @@
+package errors
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+var errorPool = sync.Pool{
+	New: func() interface{} {
+		return &fundamental{stack: emptyStack, pooled: true}
+	},
+}
+
+// NewPooled returns an error with the supplied message that is taken from a
+// pool, to avoid allocations on paths where errors are created at a very
+// high rate. Unlike New, it records no stack trace. Compared with New while
+// stack traces are disabled, it only saves the allocation of the error
+// itself. Return the error to the pool with Release once it is handled.
+//
+// Pooled errors are dangerous: once released, the error may be handed out
+// again by NewPooled with another message at any time. The error, and any
+// error wrapping it, must therefore not be retained, logged asynchronously,
+// compared or used in any other way after Release. Use it only where the
+// error provably does not escape, e.g.:
+//
+//	err := errors.NewPooled("cache miss")
+//	handle(err)
+//	errors.Release(err)
+func NewPooled(message string) error {
+	f := errorPool.Get().(*fundamental)
+	f.msg = message
+	atomic.StoreInt32(&f.released, 0)
+	return f
+}
+
+// Release returns an error created by NewPooled to the pool. Other errors,
+// including errors that wrap a pooled error, are ignored, and so is
+// releasing the same error twice.
+func Release(err error) {
+	f, ok := err.(*fundamental)
+	if !ok || !f.pooled || !atomic.CompareAndSwapInt32(&f.released, 0, 1) {
+		return
+	}
+	f.msg = ""
+	errorPool.Put(f)
+}
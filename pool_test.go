@@ -0,0 +1,65 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func Test_new_pooled(t *testing.T) {
+	err := NewPooled("cache miss")
+
+	assert.EqualError(t, err, "cache miss")
+	_, ok := FindStack(err)
+	assert.False(t, ok)
+	Release(err)
+}
+
+func Test_release_ignores_other_errors(t *testing.T) {
+	err := New("not pooled")
+	Release(err)
+	Release(Wrap(NewPooled("wrapped"), "wrap"))
+	Release(io.EOF)
+	Release(nil)
+
+	assert.EqualError(t, err, "not pooled")
+}
+
+func Test_release_resets_message(t *testing.T) {
+	err := NewPooled("cache miss")
+	f := err.(*fundamental)
+
+	Release(err)
+
+	assert.Equal(t, "", f.msg)
+}
+
+func Test_new_pooled_allocations(t *testing.T) {
+	pooled := testing.AllocsPerRun(100, func() {
+		err := NewPooled("cache miss")
+		_ = err.Error()
+		Release(err)
+	})
+	SetStackEnabled(false)
+	defer SetStackEnabled(true)
+	unpooled := testing.AllocsPerRun(100, func() {
+		_ = New("cache miss").Error()
+	})
+
+	assert.Less(t, pooled, unpooled)
+}
+
+func Test_release_twice(t *testing.T) {
+	err := NewPooled("cache miss")
+	Release(err)
+	Release(err)
+
+	first := NewPooled("first")
+	second := NewPooled("second")
+
+	assert.NotSame(t, first, second)
+	assert.EqualError(t, first, "first")
+	assert.EqualError(t, second, "second")
+	Release(first)
+	Release(second)
+}
@@ -0,0 +1,74 @@
+package errors
+
+import (
+	"fmt"
+	syslog "github.com/confetti-framework/syslog/log_level"
+)
+
+// WithPublicMessage annotates err with a message that is safe to show to
+// clients, as opposed to the message of err itself, which may contain
+// internal details. If err is nil, WithPublicMessage returns nil.
+func WithPublicMessage(err error, message string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	if len(args) > 0 {
+		message = fmt.Sprintf(message, args...)
+	}
+	return &withPublicMessage{
+		err,
+		message,
+	}
+}
+
+// FindPublicMessage returns the outermost public message in the chain of err.
+func FindPublicMessage(err error) (string, bool) {
+	var publicHolder *withPublicMessage
+
+	if !As(err, &publicHolder) {
+		return "", false
+	}
+
+	return publicHolder.public, true
+}
+
+type withPublicMessage struct {
+	cause  error
+	public string
+}
+
+func (w *withPublicMessage) Error() string {
+	return w.cause.Error()
+}
+
+func (w *withPublicMessage) Format(st fmt.State, verb rune) {
+	formatCause(st, verb, w, w.cause)
+}
+
+func (w *withPublicMessage) Unwrap() error {
+	return w.cause
+}
+
+func (w *withPublicMessage) Cause() error {
+	return Unwrap(w)
+}
+
+func (w *withPublicMessage) Wrap(message string, args ...interface{}) *withMessage {
+	return newMessage(w, message, args...)
+}
+
+func (w *withPublicMessage) Level(level syslog.Level) *withLevel {
+	return withLevelAt(w, level, 1)
+}
+
+func (w *withPublicMessage) Status(status int) *withStatus {
+	return WithStatus(w, status)
+}
+
+func (w *withPublicMessage) Alert() *withAlert {
+	return &withAlert{w}
+}
+
+func (w *withPublicMessage) With(key string, value interface{}) *withField {
+	return &withField{cause: w, key: key, value: value}
+}
@@ -0,0 +1,26 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func Test_public_message_with_nil(t *testing.T) {
+	assert.Nil(t, WithPublicMessage(nil, "try again later"))
+}
+
+func Test_public_message_without_public_message(t *testing.T) {
+	message, ok := FindPublicMessage(New("connection refused"))
+	assert.False(t, ok)
+	assert.Equal(t, "", message)
+}
+
+func Test_public_message_from_unwrap(t *testing.T) {
+	err := Wrap(WithPublicMessage(io.EOF, "user %d not found", 12), "database error")
+
+	message, ok := FindPublicMessage(err)
+	assert.True(t, ok)
+	assert.Equal(t, "user 12 not found", message)
+	assert.Equal(t, "database error: EOF", err.Error())
+}
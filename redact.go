@@ -0,0 +1,29 @@
+package errors
+
+import (
+	"regexp"
+)
+
+// DefaultRedactPatterns are used by RedactMessage when no patterns are
+// given. They match email addresses and bearer tokens.
+var DefaultRedactPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`),
+	regexp.MustCompile(`(?i)bearer\s+[A-Za-z0-9\-._~+/]+=*`),
+}
+
+// RedactMessage returns the message of err with every match of patterns
+// replaced by replacement, e.g. to remove personal data before errors are
+// shipped to a third party. With no patterns, DefaultRedactPatterns is used.
+func RedactMessage(err error, patterns []*regexp.Regexp, replacement string) string {
+	if err == nil {
+		return ""
+	}
+	if len(patterns) == 0 {
+		patterns = DefaultRedactPatterns
+	}
+	message := err.Error()
+	for _, pattern := range patterns {
+		message = pattern.ReplaceAllLiteralString(message, replacement)
+	}
+	return message
+}
@@ -0,0 +1,30 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+func Test_redact_message_nil(t *testing.T) {
+	assert.Equal(t, "", RedactMessage(nil, nil, "***"))
+}
+
+func Test_redact_message_email(t *testing.T) {
+	err := Wrap(New("user john.doe+test@example.com not found"), "login failed")
+
+	assert.Equal(t, "login failed: user *** not found", RedactMessage(err, nil, "***"))
+}
+
+func Test_redact_message_bearer_token(t *testing.T) {
+	err := New("invalid header Authorization: Bearer abc.DEF-123_456==")
+
+	assert.Equal(t, "invalid header Authorization: [redacted]", RedactMessage(err, nil, "[redacted]"))
+}
+
+func Test_redact_message_custom_patterns(t *testing.T) {
+	err := New("card 4111-1111-1111-1111 declined for jane@example.com")
+	patterns := []*regexp.Regexp{regexp.MustCompile(`\d{4}(-\d{4}){3}`)}
+
+	assert.Equal(t, "card $1 declined for jane@example.com", RedactMessage(err, patterns, "$1"))
+}
@@ -0,0 +1,105 @@
+package errors
+
+import (
+	syslog "github.com/confetti-framework/syslog/log_level"
+	"sync"
+)
+
+type statusEntry struct {
+	sentinel error
+	status   int
+}
+
+type levelEntry struct {
+	sentinel error
+	level    syslog.Level
+}
+
+var (
+	registryMu       sync.RWMutex
+	registeredStatus []statusEntry
+	registeredLevel  []levelEntry
+)
+
+// RegisterStatus declares the status for every error that matches sentinel
+// (using Is). FindStatus falls back to the registered statuses when no status
+// is attached to the chain itself, so an explicit status always wins.
+// Sentinels are consulted in the order they were registered. Registering the
+// same sentinel again replaces its status.
+func RegisterStatus(sentinel error, status int) {
+	if sentinel == nil {
+		return
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	// The entries are copied on write, so readers can match against a
+	// snapshot without holding the lock.
+	entries := append([]statusEntry(nil), registeredStatus...)
+	for i, entry := range entries {
+		if sameError(entry.sentinel, sentinel) {
+			entries[i].status = status
+			registeredStatus = entries
+			return
+		}
+	}
+	registeredStatus = append(entries, statusEntry{sentinel, status})
+}
+
+// registeredStatusOf returns the status of the first registered sentinel
+// that matches err. Is may call into user code, so the lock is not held
+// while matching.
+func registeredStatusOf(err error) (int, bool) {
+	registryMu.RLock()
+	entries := registeredStatus
+	registryMu.RUnlock()
+	for _, entry := range entries {
+		if is(err, entry.sentinel) {
+			return entry.status, true
+		}
+	}
+	return 0, false
+}
+
+// RegisterLevel declares the level for every error that matches sentinel
+// (using Is). FindLevel falls back to the registered levels when no level is
+// attached to the chain itself, so an explicit level always wins.
+// Sentinels are consulted in the order they were registered. Registering the
+// same sentinel again replaces its level.
+func RegisterLevel(sentinel error, level syslog.Level) {
+	if sentinel == nil {
+		return
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	entries := append([]levelEntry(nil), registeredLevel...)
+	for i, entry := range entries {
+		if sameError(entry.sentinel, sentinel) {
+			entries[i].level = level
+			registeredLevel = entries
+			return
+		}
+	}
+	registeredLevel = append(entries, levelEntry{sentinel, level})
+}
+
+// registeredLevelOf returns the level of the first registered sentinel
+// that matches err, without holding the lock while matching.
+func registeredLevelOf(err error) (syslog.Level, bool) {
+	registryMu.RLock()
+	entries := registeredLevel
+	registryMu.RUnlock()
+	for _, entry := range entries {
+		if is(err, entry.sentinel) {
+			return entry.level, true
+		}
+	}
+	return 0, false
+}
+
+// resetRegistry removes all registered statuses and levels.
+func resetRegistry() {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registeredStatus = nil
+	registeredLevel = nil
+}
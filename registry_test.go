@@ -0,0 +1,137 @@
+package errors
+
+import (
+	"github.com/confetti-framework/syslog/log_level"
+	"github.com/stretchr/testify/assert"
+	net "net/http"
+	"testing"
+)
+
+var errRegistryNotFound = New("record not found")
+
+type loggerFunc func(level log_level.Level, message string)
+
+func (f loggerFunc) Log(level log_level.Level, message string) {
+	f(level, message)
+}
+
+func Test_registered_status(t *testing.T) {
+	t.Cleanup(resetRegistry)
+	RegisterStatus(errRegistryNotFound, net.StatusNotFound)
+
+	status, ok := FindStatus(Wrap(errRegistryNotFound, "user 1"))
+	assert.True(t, ok)
+	assert.Equal(t, net.StatusNotFound, status)
+}
+
+func Test_registered_status_explicit_status_wins(t *testing.T) {
+	t.Cleanup(resetRegistry)
+	RegisterStatus(errRegistryNotFound, net.StatusNotFound)
+
+	status, ok := FindStatus(WithStatus(errRegistryNotFound, net.StatusGone))
+	assert.True(t, ok)
+	assert.Equal(t, net.StatusGone, status)
+}
+
+func Test_registered_status_replaced(t *testing.T) {
+	t.Cleanup(resetRegistry)
+	sentinel := New("replaced")
+	RegisterStatus(sentinel, net.StatusNotFound)
+	RegisterStatus(sentinel, net.StatusGone)
+
+	status, ok := FindStatus(sentinel)
+	assert.True(t, ok)
+	assert.Equal(t, net.StatusGone, status)
+}
+
+func Test_registered_status_not_matching(t *testing.T) {
+	t.Cleanup(resetRegistry)
+	RegisterStatus(errRegistryNotFound, net.StatusNotFound)
+
+	status, ok := FindStatus(New("record not found"))
+	assert.False(t, ok)
+	assert.Equal(t, net.StatusInternalServerError, status)
+}
+
+var errRegistryValidation = New("validation failed")
+
+func Test_registered_level(t *testing.T) {
+	t.Cleanup(resetRegistry)
+	RegisterLevel(errRegistryValidation, log_level.WARNING)
+
+	level, ok := FindLevel(Wrap(errRegistryValidation, "name is required"))
+	assert.True(t, ok)
+	assert.Equal(t, log_level.WARNING, level)
+}
+
+func Test_registered_level_explicit_level_wins(t *testing.T) {
+	t.Cleanup(resetRegistry)
+	RegisterLevel(errRegistryValidation, log_level.WARNING)
+
+	level, ok := FindLevel(WithLevel(errRegistryValidation, log_level.DEBUG))
+	assert.True(t, ok)
+	assert.Equal(t, log_level.DEBUG, level)
+}
+
+func Test_registered_level_not_matching(t *testing.T) {
+	t.Cleanup(resetRegistry)
+	RegisterLevel(errRegistryValidation, log_level.WARNING)
+
+	level, ok := FindLevel(New("validation failed"))
+	assert.False(t, ok)
+	assert.Equal(t, log_level.EMERGENCY, level)
+}
+
+func Test_registered_status_uncomparable_sentinel(t *testing.T) {
+	t.Cleanup(resetRegistry)
+	sentinel := multiError{New("first"), New("second")}
+
+	assert.NotPanics(t, func() {
+		RegisterStatus(sentinel, net.StatusNotFound)
+		RegisterStatus(sentinel, net.StatusGone)
+	})
+}
+
+func Test_registered_status_logger_may_find_status(t *testing.T) {
+	t.Cleanup(resetRegistry)
+	t.Cleanup(resetDeprecations)
+	errOld := New("old registry sentinel")
+	errNew := New("new registry sentinel")
+	RegisterStatus(errOld, net.StatusGone)
+	Deprecate(errOld, errNew, "errOld is deprecated")
+	SetLogger(loggerFunc(func(level log_level.Level, message string) {
+		RegisterStatus(errNew, net.StatusGone)
+	}))
+	defer SetLogger(nil)
+
+	status, ok := FindStatus(errNew)
+	assert.True(t, ok)
+	assert.Equal(t, net.StatusGone, status)
+}
+
+func Test_registered_level_uncomparable_sentinel(t *testing.T) {
+	t.Cleanup(resetRegistry)
+	sentinel := multiError{New("first"), New("second")}
+
+	assert.NotPanics(t, func() {
+		RegisterLevel(sentinel, log_level.WARNING)
+		RegisterLevel(sentinel, log_level.NOTICE)
+	})
+}
+
+func Test_registered_level_logger_may_find_level(t *testing.T) {
+	t.Cleanup(resetRegistry)
+	t.Cleanup(resetDeprecations)
+	errOld := New("old level sentinel")
+	errNew := New("new level sentinel")
+	RegisterLevel(errOld, log_level.WARNING)
+	Deprecate(errOld, errNew, "errOld is deprecated")
+	SetLogger(loggerFunc(func(level log_level.Level, message string) {
+		RegisterLevel(errNew, log_level.WARNING)
+	}))
+	defer SetLogger(nil)
+
+	level, ok := FindLevel(errNew)
+	assert.True(t, ok)
+	assert.Equal(t, log_level.WARNING, level)
+}
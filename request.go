@@ -0,0 +1,82 @@
+package errors
+
+import (
+	net "net/http"
+)
+
+// requestField is the field in which WithRequest stores the RequestInfo.
+const requestField = "request"
+
+// redacted replaces the values of the headers in DefaultRedactHeaders.
+const redacted = "[redacted]"
+
+// DefaultRequestHeaders are the headers that WithRequest records. Other
+// headers are left out, since they may hold credentials or personal data.
+var DefaultRequestHeaders = []string{
+	"Accept",
+	"Accept-Language",
+	"Content-Length",
+	"Content-Type",
+	"User-Agent",
+	"X-Request-Id",
+}
+
+// DefaultRedactHeaders are the headers of which WithRequest replaces the
+// values by "[redacted]", since they hold credentials. It guards against
+// such a header being added to DefaultRequestHeaders.
+var DefaultRedactHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Proxy-Authorization",
+	"Set-Cookie",
+	"X-Api-Key",
+}
+
+// RequestInfo describes the HTTP request that caused an error.
+type RequestInfo struct {
+	Method string     `json:"method"`
+	Path   string     `json:"path"`
+	Header net.Header `json:"header,omitempty"`
+}
+
+// WithRequest annotates err with the method, path and headers of r as the
+// field "request", so they show up wherever fields are logged. Only the
+// headers in DefaultRequestHeaders are recorded, of which the values of the
+// headers in DefaultRedactHeaders are redacted. The body and the query
+// string are left out. If err or r is nil, WithRequest returns err.
+func WithRequest(err error, r *net.Request) error {
+	if err == nil || r == nil {
+		return err
+	}
+	info := RequestInfo{Method: r.Method}
+	if r.URL != nil {
+		info.Path = r.URL.Path
+	}
+	for _, key := range DefaultRequestHeaders {
+		key = net.CanonicalHeaderKey(key)
+		values, ok := r.Header[key]
+		if !ok {
+			continue
+		}
+		if info.Header == nil {
+			info.Header = net.Header{}
+		}
+		info.Header[key] = append([]string(nil), values...)
+	}
+	for _, key := range DefaultRedactHeaders {
+		if values, ok := info.Header[net.CanonicalHeaderKey(key)]; ok {
+			for i := range values {
+				values[i] = redacted
+			}
+		}
+	}
+	return WithField(err, requestField, info)
+}
+
+// FindRequestInfo returns the outermost request set by WithRequest in the
+// chain of err.
+func FindRequestInfo(err error) (RequestInfo, bool) {
+	value, _ := findField(err, requestField)
+	info, ok := value.(RequestInfo)
+	return info, ok
+}
@@ -0,0 +1,72 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	net "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func Test_with_request_nil(t *testing.T) {
+	assert.Nil(t, WithRequest(nil, httptest.NewRequest("GET", "/", nil)))
+	assert.Equal(t, io.EOF, WithRequest(io.EOF, nil))
+}
+
+func Test_with_request_method_and_path(t *testing.T) {
+	r := httptest.NewRequest("POST", "/users/12?token=secret", strings.NewReader("secret body"))
+	r.Header.Set("Accept", "application/json")
+
+	err := Wrap(WithRequest(io.EOF, r), "create user")
+
+	info, ok := FindRequestInfo(err)
+	assert.True(t, ok)
+	assert.Equal(t, "POST", info.Method)
+	assert.Equal(t, "/users/12", info.Path)
+	assert.Equal(t, "application/json", info.Header.Get("Accept"))
+	assert.Equal(t, "create user: EOF", err.Error())
+	assert.Equal(t, info, FindFields(err)["request"])
+}
+
+func Test_with_request_records_selected_headers(t *testing.T) {
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("X-Auth-Token", "secret")
+	r.Header.Set("X-Amz-Security-Token", "secret")
+	r.Header.Set("User-Agent", "curl")
+
+	info, _ := FindRequestInfo(WithRequest(io.EOF, r))
+
+	assert.Equal(t, net.Header{"User-Agent": {"curl"}}, info.Header)
+}
+
+func Test_with_request_redacts_headers(t *testing.T) {
+	defer func(headers []string) { DefaultRequestHeaders = headers }(DefaultRequestHeaders)
+	DefaultRequestHeaders = append([]string{"Authorization", "cookie"}, DefaultRequestHeaders...)
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Authorization", "Bearer secret")
+	r.Header.Add("cookie", "session=secret")
+	r.Header.Set("X-Request-Id", "abc")
+
+	info, _ := FindRequestInfo(WithRequest(io.EOF, r))
+
+	assert.Equal(t, []string{"[redacted]"}, info.Header["Authorization"])
+	assert.Equal(t, []string{"[redacted]"}, info.Header["Cookie"])
+	assert.Equal(t, "abc", info.Header.Get("X-Request-Id"))
+	assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+}
+
+func Test_find_request_info_without_request(t *testing.T) {
+	_, ok := FindRequestInfo(WithField(io.EOF, "request", "GET /"))
+
+	assert.False(t, ok)
+}
+
+func Test_with_request_json(t *testing.T) {
+	r := httptest.NewRequest(net.MethodDelete, "/users/12", nil)
+
+	data, err := MarshalJSON(WithRequest(io.EOF, r))
+
+	assert.Nil(t, err)
+	assert.Equal(t, `{"message":"EOF","fields":{"request":{"method":"DELETE","path":"/users/12"}}}`, string(data))
+}
@@ -0,0 +1,64 @@
+package errors
+
+import (
+	"strings"
+)
+
+// RequireOption is a requirement checked by Require. It returns the name of
+// the metadata that is missing from err, or "" if err satisfies it.
+type RequireOption func(err error) string
+
+// RequireStatus requires an HTTP status in the chain.
+func RequireStatus() RequireOption {
+	return func(err error) string {
+		if _, ok := FindStatus(err); !ok {
+			return "status"
+		}
+		return ""
+	}
+}
+
+// RequireLevel requires a log level in the chain.
+func RequireLevel() RequireOption {
+	return func(err error) string {
+		if _, ok := FindLevel(err); !ok {
+			return "level"
+		}
+		return ""
+	}
+}
+
+// RequireStack requires a stack trace in the chain.
+func RequireStack() RequireOption {
+	return func(err error) string {
+		if _, ok := FindStack(err); !ok {
+			return "stack"
+		}
+		return ""
+	}
+}
+
+// Require checks that err carries the metadata demanded by opts, which is
+// useful in tests of code that produces errors:
+//
+//	if err := errors.Require(err, errors.RequireStatus(), errors.RequireLevel()); err != nil {
+//	        t.Error(err)
+//	}
+//
+// Require returns nil if every requirement is satisfied, and otherwise an
+// error that lists all missing metadata.
+func Require(err error, opts ...RequireOption) error {
+	var missing []string
+	for _, opt := range opts {
+		if name := opt(err); name != "" {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	if err == nil {
+		return New("nil error is missing %s", strings.Join(missing, ", "))
+	}
+	return New("error %q is missing %s", err.Error(), strings.Join(missing, ", "))
+}
@@ -0,0 +1,36 @@
+package errors
+
+import (
+	"github.com/confetti-framework/syslog/log_level"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func Test_require_without_options(t *testing.T) {
+	assert.Nil(t, Require(io.EOF))
+}
+
+func Test_require_satisfied(t *testing.T) {
+	err := NotFound("user not found").Level(log_level.ERROR)
+
+	assert.Nil(t, Require(err, RequireStatus(), RequireLevel(), RequireStack()))
+}
+
+func Test_require_unsatisfied(t *testing.T) {
+	err := Require(io.EOF, RequireStatus(), RequireLevel(), RequireStack())
+
+	assert.EqualError(t, err, `error "EOF" is missing status, level, stack`)
+}
+
+func Test_require_partially_satisfied(t *testing.T) {
+	err := Require(Wrap(io.EOF, "read"), RequireStatus(), RequireStack())
+
+	assert.EqualError(t, err, `error "read: EOF" is missing status`)
+}
+
+func Test_require_nil_error(t *testing.T) {
+	err := Require(nil, RequireLevel())
+
+	assert.EqualError(t, err, "nil error is missing level")
+}
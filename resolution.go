@@ -0,0 +1,63 @@
+package errors
+
+import (
+	"sync"
+)
+
+// StatusResolution selects the status FindStatus reports when the chain of
+// an error holds several statuses.
+type StatusResolution int
+
+const (
+	// StatusOutermost selects the status set last, i.e. closest to the
+	// outermost error. This is the default.
+	StatusOutermost StatusResolution = iota
+	// StatusInnermost selects the status set first, i.e. closest to the
+	// root cause.
+	StatusInnermost
+	// StatusHighest selects the numerically highest status, so a 5xx beats
+	// a 4xx.
+	StatusHighest
+)
+
+var (
+	resolutionMu     sync.RWMutex
+	statusResolution = StatusOutermost
+)
+
+// SetStatusResolution sets the policy FindStatus uses when the chain of an
+// error holds several statuses.
+func SetStatusResolution(resolution StatusResolution) {
+	resolutionMu.Lock()
+	defer resolutionMu.Unlock()
+	statusResolution = resolution
+}
+
+func currentStatusResolution() StatusResolution {
+	resolutionMu.RLock()
+	defer resolutionMu.RUnlock()
+	return statusResolution
+}
+
+// resolveStatus applies resolution to statuses, ordered from outermost to
+// innermost. The outermost status is used when statuses is empty, which
+// happens when it is only reachable using As.
+func resolveStatus(resolution StatusResolution, statuses []int, outermost int) int {
+	if len(statuses) == 0 {
+		return outermost
+	}
+	switch resolution {
+	case StatusInnermost:
+		return statuses[len(statuses)-1]
+	case StatusHighest:
+		highest := statuses[0]
+		for _, status := range statuses[1:] {
+			if status > highest {
+				highest = status
+			}
+		}
+		return highest
+	default:
+		return statuses[0]
+	}
+}
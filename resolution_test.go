@@ -0,0 +1,56 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	net "net/http"
+	"testing"
+)
+
+func statusChain() error {
+	return WithStatus(WithStatus(WithStatus(io.EOF, net.StatusNotFound), net.StatusInternalServerError), net.StatusBadRequest)
+}
+
+func Test_status_resolution_outermost(t *testing.T) {
+	SetStatusResolution(StatusOutermost)
+
+	status, ok := FindStatus(statusChain())
+	assert.True(t, ok)
+	assert.Equal(t, net.StatusBadRequest, status)
+}
+
+func Test_status_resolution_innermost(t *testing.T) {
+	SetStatusResolution(StatusInnermost)
+	defer SetStatusResolution(StatusOutermost)
+
+	status, ok := FindStatus(statusChain())
+	assert.True(t, ok)
+	assert.Equal(t, net.StatusNotFound, status)
+}
+
+func Test_status_resolution_highest(t *testing.T) {
+	SetStatusResolution(StatusHighest)
+	defer SetStatusResolution(StatusOutermost)
+
+	status, ok := FindStatus(statusChain())
+	assert.True(t, ok)
+	assert.Equal(t, net.StatusInternalServerError, status)
+}
+
+func Test_status_resolution_without_status(t *testing.T) {
+	SetStatusResolution(StatusHighest)
+	defer SetStatusResolution(StatusOutermost)
+
+	status, ok := FindStatus(io.EOF)
+	assert.False(t, ok)
+	assert.Equal(t, net.StatusInternalServerError, status)
+}
+
+func Test_status_resolution_only_reachable_with_as(t *testing.T) {
+	SetStatusResolution(StatusInnermost)
+	defer SetStatusResolution(StatusOutermost)
+
+	status, ok := FindStatus(WrapCause(NotFound("user not found"), io.EOF))
+	assert.True(t, ok)
+	assert.Equal(t, net.StatusNotFound, status)
+}
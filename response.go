@@ -0,0 +1,54 @@
+package errors
+
+import (
+	"io"
+	net "net/http"
+	"strconv"
+	"strings"
+)
+
+// responseSnippetLimit is the maximum number of bytes of a response body
+// that FromResponse puts in the message.
+const responseSnippetLimit = 512
+
+// FromResponse returns an error for a response with a status outside the
+// 2xx range, or nil otherwise. The error has the status of the response, a
+// message with that status and the start of the body, and is marked as
+// retryable for statuses of 500 and above. At most 512 bytes of the body
+// are read; the caller remains responsible for closing it.
+func FromResponse(resp *net.Response) error {
+	if resp == nil || (resp.StatusCode >= 200 && resp.StatusCode < 300) {
+		return nil
+	}
+	status := resp.Status
+	if status == "" {
+		status = strconv.Itoa(resp.StatusCode) + " " + net.StatusText(resp.StatusCode)
+	}
+	message := "unexpected response " + status
+	if snippet := responseSnippet(resp.Body); snippet != "" {
+		message += ": " + snippet
+	}
+	var err error = WithStatus(newFundamental(callers(), message), resp.StatusCode)
+	if resp.StatusCode >= 500 {
+		err = WithRetryable(err)
+	}
+	return err
+}
+
+// responseSnippet reads the start of body. A body that exceeds the limit is
+// cut off and ends with an ellipsis.
+func responseSnippet(body io.Reader) string {
+	if body == nil {
+		return ""
+	}
+	data, _ := io.ReadAll(io.LimitReader(body, responseSnippetLimit+1))
+	truncated := len(data) > responseSnippetLimit
+	if truncated {
+		data = data[:responseSnippetLimit]
+	}
+	snippet := strings.TrimSpace(strings.ToValidUTF8(string(data), ""))
+	if truncated {
+		snippet += ellipsis
+	}
+	return snippet
+}
@@ -0,0 +1,62 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	net "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func response(status int, body string) *net.Response {
+	recorder := httptest.NewRecorder()
+	recorder.WriteHeader(status)
+	io.WriteString(recorder, body)
+	return recorder.Result()
+}
+
+func Test_from_response_success(t *testing.T) {
+	assert.Nil(t, FromResponse(nil))
+	assert.Nil(t, FromResponse(response(net.StatusOK, "ok")))
+	assert.Nil(t, FromResponse(response(net.StatusNoContent, "")))
+}
+
+func Test_from_response_not_found(t *testing.T) {
+	err := FromResponse(response(net.StatusNotFound, "user 12 not found\n"))
+
+	assert.EqualError(t, err, "unexpected response 404 Not Found: user 12 not found")
+	status, ok := FindStatus(err)
+	assert.True(t, ok)
+	assert.Equal(t, net.StatusNotFound, status)
+	assert.False(t, IsRetryable(err))
+	_, ok = FindStack(err)
+	assert.True(t, ok)
+}
+
+func Test_from_response_service_unavailable(t *testing.T) {
+	err := FromResponse(response(net.StatusServiceUnavailable, ""))
+
+	assert.EqualError(t, err, "unexpected response 503 Service Unavailable")
+	status, _ := FindStatus(err)
+	assert.Equal(t, net.StatusServiceUnavailable, status)
+	assert.True(t, IsRetryable(err))
+}
+
+func Test_from_response_truncates_body(t *testing.T) {
+	body := strings.Repeat("a", 2000)
+	resp := response(net.StatusBadGateway, body)
+
+	err := FromResponse(resp)
+
+	want := "unexpected response 502 Bad Gateway: " + strings.Repeat("a", responseSnippetLimit) + "..."
+	assert.EqualError(t, err, want)
+	rest, _ := io.ReadAll(resp.Body)
+	assert.Len(t, rest, 2000-responseSnippetLimit-1)
+}
+
+func Test_from_response_without_status_text(t *testing.T) {
+	err := FromResponse(&net.Response{StatusCode: net.StatusConflict})
+
+	assert.EqualError(t, err, "unexpected response 409 Conflict")
+}
@@ -0,0 +1,86 @@
+package errors
+
+import (
+	"fmt"
+	syslog "github.com/confetti-framework/syslog/log_level"
+	"strconv"
+	"time"
+)
+
+// WithRetryAfter annotates err with the duration a client should wait before
+// retrying. WriteHTTP writes it as a Retry-After header in seconds.
+// If err is nil, WithRetryAfter returns nil.
+func WithRetryAfter(err error, d time.Duration) error {
+	if err == nil {
+		return nil
+	}
+	return &withRetryAfter{
+		err,
+		d,
+	}
+}
+
+// FindRetryAfter returns the outermost retry duration in the chain of err.
+func FindRetryAfter(err error) (time.Duration, bool) {
+	var retryHolder *withRetryAfter
+
+	if !As(err, &retryHolder) {
+		return 0, false
+	}
+
+	return retryHolder.retryAfter, true
+}
+
+// retryAfterSeconds formats d as the value of a Retry-After header. Partial
+// seconds are rounded up, so clients never retry too early.
+func retryAfterSeconds(d time.Duration) string {
+	seconds := int64(d / time.Second)
+	if d%time.Second > 0 {
+		seconds++
+	}
+	if seconds < 0 {
+		seconds = 0
+	}
+	return strconv.FormatInt(seconds, 10)
+}
+
+type withRetryAfter struct {
+	cause      error
+	retryAfter time.Duration
+}
+
+func (w *withRetryAfter) Error() string {
+	return w.cause.Error()
+}
+
+func (w *withRetryAfter) Format(st fmt.State, verb rune) {
+	formatCause(st, verb, w, w.cause)
+}
+
+func (w *withRetryAfter) Unwrap() error {
+	return w.cause
+}
+
+func (w *withRetryAfter) Cause() error {
+	return Unwrap(w)
+}
+
+func (w *withRetryAfter) Wrap(message string, args ...interface{}) *withMessage {
+	return newMessage(w, message, args...)
+}
+
+func (w *withRetryAfter) Level(level syslog.Level) *withLevel {
+	return withLevelAt(w, level, 1)
+}
+
+func (w *withRetryAfter) Status(status int) *withStatus {
+	return WithStatus(w, status)
+}
+
+func (w *withRetryAfter) Alert() *withAlert {
+	return &withAlert{w}
+}
+
+func (w *withRetryAfter) With(key string, value interface{}) *withField {
+	return &withField{cause: w, key: key, value: value}
+}
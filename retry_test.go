@@ -0,0 +1,54 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	net "net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func Test_retry_after_with_nil(t *testing.T) {
+	assert.Nil(t, WithRetryAfter(nil, time.Second))
+}
+
+func Test_retry_after_without_duration(t *testing.T) {
+	d, ok := FindRetryAfter(New("rate limited"))
+	assert.False(t, ok)
+	assert.Equal(t, time.Duration(0), d)
+}
+
+func Test_retry_after_from_unwrap(t *testing.T) {
+	err := Wrap(WithRetryAfter(io.EOF, 90*time.Second), "rate limited")
+
+	d, ok := FindRetryAfter(err)
+	assert.True(t, ok)
+	assert.Equal(t, 90*time.Second, d)
+}
+
+func Test_retry_after_seconds(t *testing.T) {
+	assert.Equal(t, "0", retryAfterSeconds(0))
+	assert.Equal(t, "1", retryAfterSeconds(100*time.Millisecond))
+	assert.Equal(t, "90", retryAfterSeconds(90*time.Second))
+	assert.Equal(t, "0", retryAfterSeconds(-time.Second))
+}
+
+func Test_retry_after_written_by_write_http(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	err := WithRetryAfter(New("rate limited").Status(net.StatusTooManyRequests), 2*time.Minute)
+
+	WriteHTTP(recorder, err)
+
+	assert.Equal(t, net.StatusTooManyRequests, recorder.Code)
+	assert.Equal(t, "120", recorder.Header().Get("Retry-After"))
+}
+
+func Test_retry_after_explicit_header_wins(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	err := WithHeaders(WithRetryAfter(io.EOF, time.Minute), net.Header{"Retry-After": {"30"}})
+
+	WriteHTTP(recorder, err)
+
+	assert.Equal(t, "30", recorder.Header().Get("Retry-After"))
+}
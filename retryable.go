@@ -0,0 +1,74 @@
+package errors
+
+import (
+	"fmt"
+	syslog "github.com/confetti-framework/syslog/log_level"
+)
+
+// WithRetryable marks err as a temporary failure, such that the operation
+// that caused it may be retried. If err is nil, WithRetryable returns nil.
+func WithRetryable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &withRetryable{err}
+}
+
+// IsRetryable reports whether any layer in the chain of err is marked with
+// WithRetryable.
+func IsRetryable(err error) bool {
+	var retryableHolder *withRetryable
+	return As(err, &retryableHolder)
+}
+
+type withRetryable struct {
+	cause error
+}
+
+func (w *withRetryable) Error() string {
+	return w.cause.Error()
+}
+
+func (w *withRetryable) Format(st fmt.State, verb rune) {
+	formatCause(st, verb, w, w.cause)
+}
+
+func (w *withRetryable) Unwrap() error {
+	return w.cause
+}
+
+func (w *withRetryable) Cause() error {
+	return Unwrap(w)
+}
+
+func (w *withRetryable) Wrap(message string, args ...interface{}) *withMessage {
+	return newMessage(w, message, args...)
+}
+
+func (w *withRetryable) Level(level syslog.Level) *withLevel {
+	return withLevelAt(w, level, 1)
+}
+
+func (w *withRetryable) Status(status int) *withStatus {
+	return WithStatus(w, status)
+}
+
+func (w *withRetryable) Alert() *withAlert {
+	return &withAlert{w}
+}
+
+func (w *withRetryable) With(key string, value interface{}) *withField {
+	return &withField{cause: w, key: key, value: value}
+}
+
+// IsTemporary reports the result of Temporary for the outermost layer in the
+// chain of err that has a Temporary() bool method, which includes the
+// errors of the net package. It returns false if no layer has one.
+func IsTemporary(err error) bool {
+	for _, link := range Links(err) {
+		if temporary, ok := link.(interface{ Temporary() bool }); ok {
+			return temporary.Temporary()
+		}
+	}
+	return false
+}
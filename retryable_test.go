@@ -0,0 +1,52 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net"
+	"testing"
+)
+
+func Test_with_retryable_nil(t *testing.T) {
+	assert.Nil(t, WithRetryable(nil))
+}
+
+func Test_is_retryable(t *testing.T) {
+	err := Wrap(WithRetryable(io.EOF), "read")
+
+	assert.True(t, IsRetryable(err))
+	assert.Equal(t, "read: EOF", err.Error())
+}
+
+func Test_is_retryable_without_mark(t *testing.T) {
+	assert.False(t, IsRetryable(Wrap(io.EOF, "read")))
+	assert.False(t, IsRetryable(nil))
+}
+
+type fakeNetError struct {
+	temporary bool
+}
+
+func (e fakeNetError) Error() string   { return "i/o timeout" }
+func (e fakeNetError) Timeout() bool   { return true }
+func (e fakeNetError) Temporary() bool { return e.temporary }
+
+var _ net.Error = fakeNetError{}
+
+func Test_is_temporary(t *testing.T) {
+	err := WithStatus(Wrap(fakeNetError{temporary: true}, "dial"), 503)
+
+	assert.True(t, IsTemporary(err))
+}
+
+func Test_is_temporary_false(t *testing.T) {
+	assert.False(t, IsTemporary(Wrap(fakeNetError{temporary: false}, "dial")))
+	assert.False(t, IsTemporary(Wrap(io.EOF, "read")))
+	assert.False(t, IsTemporary(nil))
+}
+
+func Test_is_temporary_first_layer_wins(t *testing.T) {
+	err := Join(fakeNetError{temporary: false}, fakeNetError{temporary: true})
+
+	assert.False(t, IsTemporary(err))
+}
@@ -0,0 +1,59 @@
+package errors
+
+import (
+	syslog "github.com/confetti-framework/syslog/log_level"
+	"math/rand"
+	"sync"
+)
+
+var (
+	sampleMu    sync.RWMutex
+	sampleRate  = 1.0
+	sampleLevel = syslog.ERROR
+	// sampleRandom returns a number in [0.0, 1.0); replaced in tests.
+	sampleRandom = rand.Float64
+)
+
+// SetSampleRate sets the fraction of errors that ShouldReport reports, from
+// 0 (none) to 1 (all, the default). Errors at least as severe as the level
+// set with SetSampleLevel are always reported.
+func SetSampleRate(rate float64) {
+	if rate < 0 {
+		rate = 0
+	}
+	if rate > 1 {
+		rate = 1
+	}
+	sampleMu.Lock()
+	defer sampleMu.Unlock()
+	sampleRate = rate
+}
+
+// SetSampleLevel sets the level from which ShouldReport reports every error
+// regardless of the sample rate. The default is ERROR.
+func SetSampleLevel(level syslog.Level) {
+	sampleMu.Lock()
+	defer sampleMu.Unlock()
+	sampleLevel = level
+}
+
+// ShouldReport decides whether err should be sent to telemetry, so all
+// reporters share the same sampling policy. Errors with a level at least as
+// severe as the sample level are always reported; other errors, including
+// those without a level, are reported with the sample rate as probability.
+func ShouldReport(err error) bool {
+	if err == nil {
+		return false
+	}
+	sampleMu.RLock()
+	rate, threshold, random := sampleRate, sampleLevel, sampleRandom
+	sampleMu.RUnlock()
+
+	if level, ok := FindLevel(err); ok && level <= threshold {
+		return true
+	}
+	if rate >= 1 {
+		return true
+	}
+	return random() < rate
+}
@@ -0,0 +1,83 @@
+package errors
+
+import (
+	"github.com/confetti-framework/syslog/log_level"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func setSampleRandom(random func() float64) func() {
+	sampleMu.Lock()
+	defer sampleMu.Unlock()
+	original := sampleRandom
+	sampleRandom = random
+	return func() {
+		sampleMu.Lock()
+		defer sampleMu.Unlock()
+		sampleRandom = original
+	}
+}
+
+func Test_should_report_nil(t *testing.T) {
+	assert.False(t, ShouldReport(nil))
+}
+
+func Test_should_report_rate_one(t *testing.T) {
+	SetSampleRate(1)
+
+	for i := 0; i < 100; i++ {
+		assert.True(t, ShouldReport(io.EOF))
+	}
+}
+
+func Test_should_report_rate_zero(t *testing.T) {
+	SetSampleRate(0)
+	defer SetSampleRate(1)
+
+	for i := 0; i < 100; i++ {
+		assert.False(t, ShouldReport(io.EOF))
+		assert.False(t, ShouldReport(WithLevel(io.EOF, log_level.INFO)))
+	}
+}
+
+func Test_should_report_severe_errors(t *testing.T) {
+	SetSampleRate(0)
+	defer SetSampleRate(1)
+
+	assert.True(t, ShouldReport(WithLevel(io.EOF, log_level.ERROR)))
+	assert.True(t, ShouldReport(WithLevel(io.EOF, log_level.EMERGENCY)))
+	assert.False(t, ShouldReport(WithLevel(io.EOF, log_level.WARNING)))
+
+	SetSampleLevel(log_level.WARNING)
+	defer SetSampleLevel(log_level.ERROR)
+	assert.True(t, ShouldReport(WithLevel(io.EOF, log_level.WARNING)))
+}
+
+func Test_should_report_sampled(t *testing.T) {
+	SetSampleRate(0.25)
+	defer SetSampleRate(1)
+
+	draws := []float64{0.1, 0.3, 0.2, 0.9}
+	defer setSampleRandom(func() float64 {
+		draw := draws[0]
+		draws = draws[1:]
+		return draw
+	})()
+
+	var reported []bool
+	for i := 0; i < 4; i++ {
+		reported = append(reported, ShouldReport(io.EOF))
+	}
+	assert.Equal(t, []bool{true, false, true, false}, reported)
+}
+
+func Test_sample_rate_clamped(t *testing.T) {
+	defer SetSampleRate(1)
+
+	SetSampleRate(-1)
+	assert.False(t, ShouldReport(io.EOF))
+
+	SetSampleRate(2)
+	assert.True(t, ShouldReport(io.EOF))
+}
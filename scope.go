@@ -0,0 +1,50 @@
+package errors
+
+import (
+	"context"
+	syslog "github.com/confetti-framework/syslog/log_level"
+)
+
+type defaultsKey struct{}
+
+type defaults struct {
+	// hasLevel reports whether level is set, since the zero level is
+	// EMERGENCY.
+	hasLevel bool
+	level    syslog.Level
+	status   int
+}
+
+// WithDefaults returns a copy of ctx in which errors created with NewCtx get
+// the given level and status, e.g. for all errors of a request handler.
+// A status of 0 sets no status. The defaults replace those of an outer
+// scope and are gone once the returned context is no longer used.
+func WithDefaults(ctx context.Context, level syslog.Level, status int) context.Context {
+	return context.WithValue(ctx, defaultsKey{}, defaults{hasLevel: true, level: level, status: status})
+}
+
+// WithDefaultStatus returns a copy of ctx in which errors created with
+// NewCtx get the given status, but no level. Like WithDefaults, it replaces
+// the defaults of an outer scope.
+func WithDefaultStatus(ctx context.Context, status int) context.Context {
+	return context.WithValue(ctx, defaultsKey{}, defaults{status: status})
+}
+
+// NewCtx returns an error like New, with the level and status set by
+// WithDefaults or WithDefaultStatus on ctx. Levels and statuses set on the
+// returned error take precedence over the defaults. Without defaults in ctx,
+// NewCtx is equivalent to New.
+func NewCtx(ctx context.Context, message string, args ...interface{}) error {
+	var err error = newFundamental(callers(), message, args...)
+	scope, ok := ctx.Value(defaultsKey{}).(defaults)
+	if !ok {
+		return err
+	}
+	if scope.hasLevel {
+		err = &withLevel{err, scope.level}
+	}
+	if scope.status != 0 {
+		err = WithStatus(err, scope.status)
+	}
+	return err
+}
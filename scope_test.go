@@ -0,0 +1,83 @@
+package errors
+
+import (
+	"context"
+	"github.com/confetti-framework/syslog/log_level"
+	"github.com/stretchr/testify/assert"
+	net "net/http"
+	"testing"
+)
+
+func Test_new_ctx_outside_scope(t *testing.T) {
+	err := NewCtx(context.Background(), "user %d not found", 12)
+
+	assert.EqualError(t, err, "user 12 not found")
+	_, ok := FindLevel(err)
+	assert.False(t, ok)
+	_, ok = FindStatus(err)
+	assert.False(t, ok)
+	stack, ok := FindStack(err)
+	assert.True(t, ok)
+	assert.Equal(t, "Test_new_ctx_outside_scope", funcname(stack[0].name()))
+}
+
+func Test_new_ctx_inside_scope(t *testing.T) {
+	ctx := WithDefaults(context.Background(), log_level.WARNING, net.StatusUnprocessableEntity)
+
+	err := NewCtx(ctx, "invalid name")
+
+	assert.EqualError(t, err, "invalid name")
+	level, _ := FindLevel(err)
+	assert.Equal(t, log_level.WARNING, level)
+	status, _ := FindStatus(err)
+	assert.Equal(t, net.StatusUnprocessableEntity, status)
+}
+
+func Test_new_ctx_scope_is_reset(t *testing.T) {
+	parent := context.Background()
+	_ = NewCtx(WithDefaults(parent, log_level.WARNING, net.StatusUnprocessableEntity), "inside")
+
+	_, ok := FindStatus(NewCtx(parent, "outside"))
+	assert.False(t, ok)
+}
+
+func Test_new_ctx_without_default_status(t *testing.T) {
+	ctx := WithDefaults(context.Background(), log_level.NOTICE, 0)
+
+	err := NewCtx(ctx, "invalid name")
+
+	level, _ := FindLevel(err)
+	assert.Equal(t, log_level.NOTICE, level)
+	_, ok := FindStatus(err)
+	assert.False(t, ok)
+}
+
+func Test_new_ctx_with_default_status_only(t *testing.T) {
+	ctx := WithDefaultStatus(context.Background(), net.StatusNotFound)
+
+	err := NewCtx(ctx, "user not found")
+
+	status, _ := FindStatus(err)
+	assert.Equal(t, net.StatusNotFound, status)
+	_, ok := FindLevel(err)
+	assert.False(t, ok)
+}
+
+func Test_new_ctx_inner_scope_replaces_outer(t *testing.T) {
+	outer := WithDefaults(context.Background(), log_level.WARNING, net.StatusUnprocessableEntity)
+	inner := WithDefaults(outer, log_level.ERROR, net.StatusConflict)
+
+	status, _ := FindStatus(NewCtx(inner, "conflict"))
+	assert.Equal(t, net.StatusConflict, status)
+}
+
+func Test_new_ctx_explicit_values_win(t *testing.T) {
+	ctx := WithDefaults(context.Background(), log_level.WARNING, net.StatusUnprocessableEntity)
+
+	err := WithLevel(WithStatus(NewCtx(ctx, "not found"), net.StatusNotFound), log_level.ERROR)
+
+	status, _ := FindStatus(err)
+	assert.Equal(t, net.StatusNotFound, status)
+	level, _ := FindLevel(err)
+	assert.Equal(t, log_level.ERROR, level)
+}
@@ -0,0 +1,46 @@
+package errors
+
+import (
+	syslog "github.com/confetti-framework/syslog/log_level"
+)
+
+// CompareLevel compares the severity of a and b. It returns 1 if a is more
+// severe than b, -1 if a is less severe and 0 if both are equally severe.
+// Errors without a level compare as DefaultLogLevel. To order errors from
+// the most to the least severe:
+//
+//	sort.Slice(errs, func(i, j int) bool {
+//	        return errors.CompareLevel(errs[i], errs[j]) > 0
+//	})
+func CompareLevel(a, b error) int {
+	levelA, levelB := levelOrDefault(a), levelOrDefault(b)
+	switch {
+	case levelA < levelB:
+		return 1
+	case levelA > levelB:
+		return -1
+	default:
+		return 0
+	}
+}
+
+// levelOrDefault returns the level of err, or DefaultLogLevel without one.
+func levelOrDefault(err error) syslog.Level {
+	if level, ok := FindLevel(err); ok {
+		return level
+	}
+	return DefaultLogLevel
+}
+
+// MostSevere returns the most severe of errs, as ordered by CompareLevel.
+// Of equally severe errors the first is returned. Nil errors are skipped;
+// MostSevere returns nil if every error is nil.
+func MostSevere(errs ...error) error {
+	var worst error
+	for _, err := range errs {
+		if err != nil && (worst == nil || CompareLevel(err, worst) > 0) {
+			worst = err
+		}
+	}
+	return worst
+}
@@ -0,0 +1,57 @@
+package errors
+
+import (
+	"github.com/confetti-framework/syslog/log_level"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"sort"
+	"testing"
+)
+
+func Test_compare_level(t *testing.T) {
+	critical := WithLevel(io.EOF, log_level.CRITICAL)
+	warning := WithLevel(io.EOF, log_level.WARNING)
+
+	assert.Equal(t, 1, CompareLevel(critical, warning))
+	assert.Equal(t, -1, CompareLevel(warning, critical))
+	assert.Equal(t, 0, CompareLevel(warning, WithLevel(io.ErrUnexpectedEOF, log_level.WARNING)))
+}
+
+func Test_compare_level_missing_uses_default(t *testing.T) {
+	assert.Equal(t, 0, CompareLevel(io.EOF, WithLevel(io.EOF, DefaultLogLevel)))
+	assert.Equal(t, 1, CompareLevel(io.EOF, WithLevel(io.EOF, log_level.INFO)))
+	assert.Equal(t, -1, CompareLevel(io.EOF, WithLevel(io.EOF, log_level.EMERGENCY)))
+}
+
+func Test_compare_level_sort(t *testing.T) {
+	errs := []error{
+		New("info").Level(log_level.INFO),
+		New("none"),
+		New("emergency").Level(log_level.EMERGENCY),
+		New("warning").Level(log_level.WARNING),
+		New("critical").Level(log_level.CRITICAL),
+	}
+
+	sort.SliceStable(errs, func(i, j int) bool {
+		return CompareLevel(errs[i], errs[j]) > 0
+	})
+
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	assert.Equal(t, []string{"emergency", "critical", "none", "warning", "info"}, messages)
+}
+
+func Test_most_severe(t *testing.T) {
+	critical := WithLevel(io.EOF, log_level.CRITICAL)
+	otherCritical := WithLevel(io.ErrUnexpectedEOF, log_level.CRITICAL)
+
+	assert.Equal(t, critical, MostSevere(WithLevel(io.EOF, log_level.INFO), nil, critical, otherCritical, io.EOF))
+	assert.Equal(t, io.EOF, MostSevere(WithLevel(io.EOF, log_level.WARNING), io.EOF))
+}
+
+func Test_most_severe_nil(t *testing.T) {
+	assert.Nil(t, MostSevere())
+	assert.Nil(t, MostSevere(nil, nil))
+}
@@ -0,0 +1,72 @@
+//go:build go1.21
+// +build go1.21
+
+package errors
+
+import (
+	syslog "github.com/confetti-framework/syslog/log_level"
+	"log/slog"
+	"sort"
+)
+
+// ToLogRecord returns err as a record for a slog.Handler. The record has the
+// message and level of err, or DefaultLogLevel without one, and the time
+// found by FindTime or else the current time. Its attributes are the status,
+// the codes and the fields of err, the fields in alphabetical order. With a
+// stack trace in the chain, the source of the record is its top frame.
+func ToLogRecord(err error) slog.Record {
+	if err == nil {
+		return slog.NewRecord(currentTime(), slogLevel(DefaultLogLevel), "", 0)
+	}
+	created, ok := FindTime(err)
+	if !ok {
+		created = currentTime()
+	}
+	var pc uintptr
+	if stack, ok := FindStack(err); ok && len(stack) > 0 {
+		pc = uintptr(stack[0])
+	}
+	record := slog.NewRecord(created, slogLevel(levelOrDefault(err)), err.Error(), pc)
+	if status, ok := FindStatus(err); ok {
+		record.AddAttrs(slog.Int("status", status))
+	}
+	if code, ok := FindCode(err); ok {
+		record.AddAttrs(slog.String("code", code))
+	}
+	if code, ok := FindNumericCode(err); ok {
+		record.AddAttrs(slog.Int("error_code", code))
+	}
+	fields := FindFields(err)
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		record.AddAttrs(slog.Any(key, fields[key]))
+	}
+	return record
+}
+
+// slogLevel maps a syslog level to a slog level. The levels more severe
+// than ERROR are mapped above slog.LevelError, keeping their order.
+func slogLevel(level syslog.Level) slog.Level {
+	switch level {
+	case syslog.EMERGENCY:
+		return slog.LevelError + 12
+	case syslog.ALERT:
+		return slog.LevelError + 8
+	case syslog.CRITICAL:
+		return slog.LevelError + 4
+	case syslog.ERROR:
+		return slog.LevelError
+	case syslog.WARNING:
+		return slog.LevelWarn
+	case syslog.NOTICE:
+		return slog.LevelInfo + 2
+	case syslog.INFO:
+		return slog.LevelInfo
+	default:
+		return slog.LevelDebug
+	}
+}
@@ -0,0 +1,74 @@
+//go:build go1.21
+// +build go1.21
+
+package errors
+
+import (
+	"github.com/confetti-framework/syslog/log_level"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"log/slog"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func recordAttrs(record slog.Record) map[string]interface{} {
+	attrs := map[string]interface{}{}
+	record.Attrs(func(attr slog.Attr) bool {
+		attrs[attr.Key] = attr.Value.Any()
+		return true
+	})
+	return attrs
+}
+
+func Test_to_log_record(t *testing.T) {
+	err := WithField(WithCode(NotFound("user %d not found", 12).Level(log_level.WARNING), "E1"), "user_id", 12)
+
+	record := ToLogRecord(err)
+
+	assert.Equal(t, "user 12 not found", record.Message)
+	assert.Equal(t, slog.LevelWarn, record.Level)
+	assert.Equal(t, map[string]interface{}{
+		"status":  int64(404),
+		"code":    "E1",
+		"user_id": int64(12),
+	}, recordAttrs(record))
+	frame, _ := runtime.CallersFrames([]uintptr{record.PC}).Next()
+	assert.Equal(t, "Test_to_log_record", funcname(frame.Function))
+}
+
+func Test_to_log_record_default_level_and_time(t *testing.T) {
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	SetClock(func() time.Time { return now })
+	defer SetClock(nil)
+
+	record := ToLogRecord(io.EOF)
+
+	assert.Equal(t, slog.LevelError, record.Level)
+	assert.Equal(t, now, record.Time)
+	assert.Equal(t, 0, record.NumAttrs())
+	assert.Equal(t, uintptr(0), record.PC)
+}
+
+func Test_to_log_record_time_from_find_time(t *testing.T) {
+	created := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	SetClock(func() time.Time { return created })
+	err := WithTime(io.EOF)
+	SetClock(nil)
+
+	assert.Equal(t, created, ToLogRecord(err).Time)
+}
+
+func Test_slog_level_order(t *testing.T) {
+	levels := []log_level.Level{
+		log_level.DEBUG, log_level.INFO, log_level.NOTICE, log_level.WARNING,
+		log_level.ERROR, log_level.CRITICAL, log_level.ALERT, log_level.EMERGENCY,
+	}
+	for i := 1; i < len(levels); i++ {
+		assert.True(t, slogLevel(levels[i-1]) < slogLevel(levels[i]))
+	}
+	assert.Equal(t, slog.LevelError, slogLevel(log_level.ERROR))
+	assert.Equal(t, slog.LevelInfo, slogLevel(log_level.INFO))
+	assert.Equal(t, slog.LevelDebug, slogLevel(log_level.DEBUG))
+}
@@ -7,6 +7,7 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync/atomic"
 )
 
 // Frame represents a program counter inside a stack frame.
@@ -21,6 +22,9 @@ func (f Frame) pc() uintptr { return uintptr(f) - 1 }
 // file returns the full path to the file that contains the
 // function for this Frame's pc.
 func (f Frame) file() string {
+	if info, ok := cachedFrameInfo(f); ok {
+		return info.File
+	}
 	fn := runtime.FuncForPC(f.pc())
 	if fn == nil {
 		return "unknown"
@@ -32,6 +36,9 @@ func (f Frame) file() string {
 // line returns the line number of source code of the
 // function for this Frame's pc.
 func (f Frame) line() int {
+	if info, ok := cachedFrameInfo(f); ok {
+		return info.Line
+	}
 	fn := runtime.FuncForPC(f.pc())
 	if fn == nil {
 		return 0
@@ -42,6 +49,9 @@ func (f Frame) line() int {
 
 // name returns the name of this function, if known.
 func (f Frame) name() string {
+	if info, ok := cachedFrameInfo(f); ok {
+		return info.Function
+	}
 	fn := runtime.FuncForPC(f.pc())
 	if fn == nil {
 		return "unknown"
@@ -93,6 +103,29 @@ func (f Frame) MarshalText() ([]byte, error) {
 	return []byte(fmt.Sprintf("%s %s:%d", name, f.file(), f.line())), nil
 }
 
+// Location returns the full path of the source file and the line number of
+// the frame as "path:line", which terminals of most editors turn into a
+// link to the source.
+func (f Frame) Location() string {
+	return f.file() + ":" + strconv.Itoa(f.line())
+}
+
+// FrameInfo is the symbolized form of a Frame.
+type FrameInfo struct {
+	Function string
+	File     string
+	Line     int
+}
+
+// Info returns the function name, source file and line number of the frame.
+func (f Frame) Info() FrameInfo {
+	return FrameInfo{
+		Function: f.name(),
+		File:     f.file(),
+		Line:     f.line(),
+	}
+}
+
 // StackTrace is stack of Frames from innermost (newest) to outermost (oldest).
 type StackTrace []Frame
 
@@ -109,8 +142,13 @@ func (st StackTrace) Format(s fmt.State, verb rune) {
 	case 'v':
 		switch {
 		case s.Flag('+'):
+			formatter := currentFrameFormatter()
 			for _, f := range st {
 				io.WriteString(s, "\n")
+				if formatter != nil {
+					io.WriteString(s, formatter(f.Info()))
+					continue
+				}
 				f.Format(s, verb)
 			}
 		case s.Flag('#'):
@@ -123,6 +161,21 @@ func (st StackTrace) Format(s fmt.State, verb rune) {
 	}
 }
 
+// EqualIgnoringLines reports whether st and other consist of the same
+// functions in the same source files, ignoring line numbers. This keeps
+// comparisons stable when code moves within a function.
+func (st StackTrace) EqualIgnoringLines(other StackTrace) bool {
+	if len(st) != len(other) {
+		return false
+	}
+	for i := range st {
+		if st[i].name() != other[i].name() || st[i].file() != other[i].file() {
+			return false
+		}
+	}
+	return true
+}
+
 // formatSlice will format this StackTrace into the given buffer as a slice of
 // Frame, only valid when called with '%s' or '%v'.
 func (st StackTrace) formatSlice(s fmt.State, verb rune) {
@@ -144,8 +197,13 @@ func (s *stack) Format(st fmt.State, verb rune) {
 	case 'v':
 		switch {
 		case st.Flag('+'):
-			for _, pc := range *s {
+			formatter := currentFrameFormatter()
+			for _, pc := range s.frames() {
 				f := Frame(pc)
+				if formatter != nil {
+					io.WriteString(st, "\n"+formatter(f.Info()))
+					continue
+				}
 				fmt.Fprintf(st, "\n%+v", f)
 			}
 		}
@@ -153,18 +211,47 @@ func (s *stack) Format(st fmt.State, verb rune) {
 }
 
 func (s *stack) StackTrace() StackTrace {
-	f := make([]Frame, len(*s))
+	pcs := s.frames()
+	f := make([]Frame, len(pcs))
 	for i := 0; i < len(f); i++ {
-		f[i] = Frame((*s)[i])
+		f[i] = Frame(pcs[i])
 	}
 	return f
 }
 
+// frames returns the program counters of s, trimmed if SetTrimStack is on.
+func (s *stack) frames() []uintptr {
+	if trimStackEnabled() {
+		return trimFrames(*s)
+	}
+	return *s
+}
+
 func callers() *stack {
-	const depth = 32
-	var pcs [depth]uintptr
-	n := runtime.Callers(3, pcs[:])
-	var st stack = pcs[0:n]
+	if !stackEnabled() || (stackBudgetActive() && !withinStackBudget(callerPC(2))) {
+		return emptyStack
+	}
+	if atomic.LoadInt32(&customCapturer) == 1 {
+		var st stack = currentStackCapturer().Capture(2)
+		return &st
+	}
+	var pcs [stackDepth]uintptr
+	var st stack = pcs[:defaultCapture(2, pcs[:])]
+	return &st
+}
+
+// callersAt records the stack trace starting skip frames above the caller
+// of callersAt; callersAt(1) is equivalent to callers().
+func callersAt(skip int) *stack {
+	if !stackEnabled() || (stackBudgetActive() && !withinStackBudget(callerPC(skip+1))) {
+		return emptyStack
+	}
+	if atomic.LoadInt32(&customCapturer) == 1 {
+		var st stack = currentStackCapturer().Capture(skip + 1)
+		return &st
+	}
+	var pcs [stackDepth]uintptr
+	var st stack = pcs[:defaultCapture(skip+1, pcs[:])]
 	return &st
 }
 
@@ -0,0 +1,33 @@
+package errors
+
+// stackDepthField is the field WithStackDepth records the depth in.
+const stackDepthField = "stack_depth"
+
+// WithStackDepth annotates err with the number of frames on the stack at
+// the point WithStackDepth is called, as the field "stack_depth", e.g. to
+// diagnose runaway recursion. It stores a single number instead of a stack
+// trace, but walks up to 1024 frames to count them; deeper stacks are
+// recorded as 1024. Where the depth cannot be determined, such as under
+// TinyGo, err is returned unchanged. If err is nil, WithStackDepth returns
+// nil.
+func WithStackDepth(err error) error {
+	if err == nil {
+		return nil
+	}
+	depth, ok := callerDepth(1)
+	if !ok {
+		return err
+	}
+	return WithField(err, stackDepthField, depth)
+}
+
+// FindStackDepth returns the depth recorded by WithStackDepth in the chain
+// of err.
+func FindStackDepth(err error) (int, bool) {
+	value, ok := findField(err, stackDepthField)
+	if !ok {
+		return 0, false
+	}
+	depth, ok := value.(int)
+	return depth, ok
+}
@@ -0,0 +1,40 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func recurse(depth int) error {
+	if depth == 0 {
+		return WithStackDepth(io.EOF)
+	}
+	return recurse(depth - 1)
+}
+
+func Test_with_stack_depth_nil(t *testing.T) {
+	assert.Nil(t, WithStackDepth(nil))
+}
+
+func Test_with_stack_depth_at_recursion_depth(t *testing.T) {
+	base, ok := FindStackDepth(recurse(0))
+	assert.True(t, ok)
+	assert.True(t, base > 0)
+
+	depth, ok := FindStackDepth(Wrap(recurse(100), "recurse"))
+	assert.True(t, ok)
+	assert.Equal(t, base+100, depth)
+	assert.Equal(t, depth, FindFields(recurse(100))["stack_depth"])
+}
+
+func Test_find_stack_depth_without_depth(t *testing.T) {
+	_, ok := FindStackDepth(Wrap(io.EOF, "read"))
+	assert.False(t, ok)
+}
+
+func Test_with_stack_depth_capped(t *testing.T) {
+	depth, ok := FindStackDepth(recurse(2 * maxCallerDepth))
+	assert.True(t, ok)
+	assert.Equal(t, maxCallerDepth, depth)
+}
@@ -0,0 +1,56 @@
+package errors
+
+import (
+	syslog "github.com/confetti-framework/syslog/log_level"
+	"sync"
+)
+
+var (
+	stackLevelMu        sync.RWMutex
+	stackLevelEnabled   bool
+	stackLevelThreshold syslog.Level
+)
+
+// SetStackLevelThreshold makes stack traces depend on the severity of an
+// error. Setting a level that is at least as severe as level records a stack
+// trace (if the chain has none yet), while FindStack reports no stack trace
+// for errors with a less severe level. Errors without a level are not
+// affected.
+//
+// New, Wrap and WithStack still record their stack trace eagerly, because
+// the level is not known yet at that point; the threshold only hides it.
+func SetStackLevelThreshold(level syslog.Level) {
+	stackLevelMu.Lock()
+	defer stackLevelMu.Unlock()
+	stackLevelEnabled = true
+	stackLevelThreshold = level
+}
+
+// ClearStackLevelThreshold removes the threshold set with
+// SetStackLevelThreshold.
+func ClearStackLevelThreshold() {
+	stackLevelMu.Lock()
+	defer stackLevelMu.Unlock()
+	stackLevelEnabled = false
+}
+
+// meetsStackLevelThreshold reports whether a threshold is configured and
+// level is at least as severe. A lower level is more severe.
+func meetsStackLevelThreshold(level syslog.Level) bool {
+	stackLevelMu.RLock()
+	defer stackLevelMu.RUnlock()
+	return stackLevelEnabled && level <= stackLevelThreshold
+}
+
+// belowStackLevelThreshold reports whether a threshold is configured and the
+// level of err is less severe.
+func belowStackLevelThreshold(err error) bool {
+	stackLevelMu.RLock()
+	enabled, threshold := stackLevelEnabled, stackLevelThreshold
+	stackLevelMu.RUnlock()
+	if !enabled {
+		return false
+	}
+	level, ok := FindLevel(err)
+	return ok && level > threshold
+}
@@ -0,0 +1,78 @@
+package errors
+
+import (
+	"github.com/confetti-framework/syslog/log_level"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func Test_stack_level_threshold_not_set(t *testing.T) {
+	ClearStackLevelThreshold()
+
+	_, ok := FindStack(WithLevel(io.EOF, log_level.EMERGENCY))
+	assert.False(t, ok)
+
+	_, ok = FindStack(New("debug info").Level(log_level.DEBUG))
+	assert.True(t, ok)
+}
+
+func Test_stack_level_threshold_above(t *testing.T) {
+	SetStackLevelThreshold(log_level.ERROR)
+	defer ClearStackLevelThreshold()
+
+	stack, ok := FindStack(WithLevel(io.EOF, log_level.CRITICAL))
+	assert.True(t, ok)
+	assert.Equal(t, "Test_stack_level_threshold_above", funcname(stack[0].name()))
+}
+
+func Test_stack_level_threshold_equal(t *testing.T) {
+	SetStackLevelThreshold(log_level.ERROR)
+	defer ClearStackLevelThreshold()
+
+	_, ok := FindStack(WithLevel(io.EOF, log_level.ERROR))
+	assert.True(t, ok)
+}
+
+func Test_stack_level_threshold_fluent(t *testing.T) {
+	SetStackLevelThreshold(log_level.ERROR)
+	defer ClearStackLevelThreshold()
+
+	err := WithStatus(io.EOF, 500).Level(log_level.ERROR)
+
+	stack, ok := FindStack(err)
+	assert.True(t, ok)
+	assert.Equal(t, "Test_stack_level_threshold_fluent", funcname(stack[0].name()))
+	assert.Equal(t, "EOF", err.Error())
+}
+
+func Test_stack_level_threshold_keeps_existing_stack(t *testing.T) {
+	SetStackLevelThreshold(log_level.ERROR)
+	defer ClearStackLevelThreshold()
+
+	inner := New("database error")
+	err := inner.Level(log_level.ERROR)
+
+	stack, ok := FindStack(err)
+	assert.True(t, ok)
+	assert.Equal(t, inner.StackTrace(), stack)
+}
+
+func Test_stack_level_threshold_below(t *testing.T) {
+	SetStackLevelThreshold(log_level.ERROR)
+	defer ClearStackLevelThreshold()
+
+	_, ok := FindStack(WithLevel(io.EOF, log_level.INFO))
+	assert.False(t, ok)
+
+	_, ok = FindStack(New("debug info").Level(log_level.DEBUG))
+	assert.False(t, ok)
+}
+
+func Test_stack_level_threshold_without_level(t *testing.T) {
+	SetStackLevelThreshold(log_level.ERROR)
+	defer ClearStackLevelThreshold()
+
+	_, ok := FindStack(New("database error"))
+	assert.True(t, ok)
+}
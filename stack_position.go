@@ -0,0 +1,52 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// StackPosition controls where %+v prints a stack trace relative to the
+// message it belongs to.
+type StackPosition int
+
+const (
+	// StackAfter prints the message followed by its stack trace. This is
+	// the default.
+	StackAfter StackPosition = iota
+	// StackBefore prints the stack trace followed by its message.
+	StackBefore
+)
+
+var (
+	stackPositionMu sync.RWMutex
+	stackPosition   = StackAfter
+)
+
+// SetStackPosition sets whether %+v prints stack traces before or after the
+// message they belong to.
+func SetStackPosition(position StackPosition) {
+	stackPositionMu.Lock()
+	defer stackPositionMu.Unlock()
+	stackPosition = position
+}
+
+func currentStackPosition() StackPosition {
+	stackPositionMu.RLock()
+	defer stackPositionMu.RUnlock()
+	return stackPosition
+}
+
+// formatWithStack writes message and the frames of st to s in the order
+// chosen with SetStackPosition.
+func formatWithStack(s fmt.State, st *stack, message string) {
+	if currentStackPosition() == StackBefore && st != nil && len(*st) > 0 {
+		io.WriteString(s, strings.TrimPrefix(fmt.Sprintf("%+v", st), "\n"))
+		io.WriteString(s, "\n")
+		io.WriteString(s, message)
+		return
+	}
+	io.WriteString(s, message)
+	st.Format(s, 'v')
+}
@@ -0,0 +1,50 @@
+package errors
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func Test_stack_position_after_by_default(t *testing.T) {
+	err := New("user not found")
+
+	lines := strings.Split(fmt.Sprintf("%+v", err), "\n")
+
+	assert.Equal(t, "user not found", lines[0])
+	assert.Regexp(t, regexp.MustCompile(`Test_stack_position_after_by_default$`), lines[1])
+}
+
+func Test_stack_position_before_fundamental(t *testing.T) {
+	SetStackPosition(StackBefore)
+	defer SetStackPosition(StackAfter)
+	err := New("user not found")
+
+	lines := strings.Split(fmt.Sprintf("%+v", err), "\n")
+
+	assert.Regexp(t, regexp.MustCompile(`Test_stack_position_before_fundamental$`), lines[0])
+	assert.Equal(t, "user not found", lines[len(lines)-1])
+}
+
+func Test_stack_position_before_wrap(t *testing.T) {
+	SetStackPosition(StackBefore)
+	defer SetStackPosition(StackAfter)
+	err := Wrap(io.EOF, "load user")
+
+	lines := strings.Split(fmt.Sprintf("%+v", err), "\n")
+
+	assert.Regexp(t, regexp.MustCompile(`Test_stack_position_before_wrap$`), lines[0])
+	assert.Equal(t, []string{"EOF", "load user"}, lines[len(lines)-2:])
+}
+
+func Test_stack_position_before_without_stack(t *testing.T) {
+	SetStackPosition(StackBefore)
+	defer SetStackPosition(StackAfter)
+	SetStackEnabled(false)
+	defer SetStackEnabled(true)
+
+	assert.Equal(t, "user not found", fmt.Sprintf("%+v", New("user not found")))
+}
@@ -149,7 +149,7 @@ func TestStackTrace(t *testing.T) {
 				return New("hello %s", fmt.Sprintf("world: %s", "ooh"))
 			}()
 		}()), []string{
-			`github.com/confetti-framework/errors.TestStackTrace.func2.1` +
+			`github.com/confetti-framework/errors.TestStackTrace.func2.(func)?1` +
 				"\n\t.+/errors/stack_test.go:149", // this is the stack of New
 			`github.com/confetti-framework/errors.TestStackTrace.func2` +
 				"\n\t.+/errors/stack_test.go:150", // this is the stack of New's caller
@@ -276,3 +276,73 @@ func caller() Frame {
 	frame, _ := frames.Next()
 	return Frame(frame.PC)
 }
+
+func TestFrameInfo(t *testing.T) {
+	info := caller().Info()
+
+	assert.Equal(t, "github.com/confetti-framework/errors.TestFrameInfo", info.Function)
+	assert.Contains(t, info.File, "stack_test.go")
+	assert.True(t, info.Line > 0)
+	assert.Equal(t, FrameInfo{"unknown", "unknown", 0}, Frame(0).Info())
+}
+
+func newInner() error {
+	return New("inner")
+}
+
+func wrapOuter(err error) error {
+	return Wrap(err, "outer")
+}
+
+func TestFindStackWhere(t *testing.T) {
+	err := wrapOuter(newInner())
+
+	stack, ok := FindStackWhere(err, func(info FrameInfo) bool {
+		return funcname(info.Function) == "newInner"
+	})
+	assert.True(t, ok)
+	assert.Equal(t, "newInner", funcname(stack[0].name()))
+
+	stack, ok = FindStackWhere(err, func(info FrameInfo) bool {
+		return funcname(info.Function) == "wrapOuter"
+	})
+	assert.True(t, ok)
+	assert.Equal(t, "wrapOuter", funcname(stack[0].name()))
+}
+
+func TestFindStackWhereWithoutMatch(t *testing.T) {
+	_, ok := FindStackWhere(wrapOuter(newInner()), func(info FrameInfo) bool {
+		return false
+	})
+	assert.False(t, ok)
+}
+
+func TestStackTraceEqualIgnoringLines(t *testing.T) {
+	first := New("first").StackTrace()
+	second := New("second").StackTrace()
+
+	assert.NotEqual(t, first[0].line(), second[0].line())
+	assert.True(t, first.EqualIgnoringLines(second))
+	assert.True(t, first.EqualIgnoringLines(first))
+}
+
+func TestStackTraceEqualIgnoringLinesDifferentFunction(t *testing.T) {
+	first := New("first").StackTrace()
+	second := func() StackTrace { return New("second").StackTrace() }()
+
+	assert.False(t, first.EqualIgnoringLines(second))
+	assert.False(t, first.EqualIgnoringLines(first[1:]))
+	assert.True(t, StackTrace{}.EqualIgnoringLines(nil))
+}
+
+func TestFrameLocation(t *testing.T) {
+	frame := New("location").StackTrace()[0]
+
+	location := frame.Location()
+	assert.Regexp(t, `^.+/stack_test\.go:[1-9][0-9]*$`, location)
+	assert.Equal(t, fmt.Sprintf("%s:%d", frame.file(), frame.line()), location)
+}
+
+func TestFrameLocationUnknown(t *testing.T) {
+	assert.Equal(t, "unknown:0", Frame(0).Location())
+}
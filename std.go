@@ -0,0 +1,29 @@
+package errors
+
+// StdWrap returns an error with the shape of the error returned by
+// fmt.Errorf with a %w verb: it has the message of err, carries no stack
+// trace or other annotations and its Unwrap method returns err. This helps
+// libraries that only introspect errors through the standard library. If
+// err is nil, StdWrap returns nil.
+func StdWrap(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &stdWrapError{
+		msg: err.Error(),
+		err: err,
+	}
+}
+
+type stdWrapError struct {
+	msg string
+	err error
+}
+
+func (e *stdWrapError) Error() string {
+	return e.msg
+}
+
+func (e *stdWrapError) Unwrap() error {
+	return e.err
+}
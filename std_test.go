@@ -0,0 +1,34 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func Test_std_wrap_nil(t *testing.T) {
+	assert.Nil(t, StdWrap(nil))
+}
+
+func Test_std_wrap_keeps_message(t *testing.T) {
+	err := StdWrap(Wrap(io.EOF, "read"))
+
+	assert.Equal(t, "read: EOF", err.Error())
+	assert.Equal(t, "read: EOF", fmt.Sprintf("%+v", err))
+}
+
+func Test_std_wrap_unwrap(t *testing.T) {
+	cause := Wrap(io.EOF, "read")
+	err := StdWrap(cause)
+
+	assert.Equal(t, cause, stderrors.Unwrap(err))
+	assert.True(t, stderrors.Is(err, io.EOF))
+}
+
+func Test_std_wrap_has_no_stack(t *testing.T) {
+	_, ok := StdWrap(io.EOF).(StackTracer)
+
+	assert.False(t, ok)
+}
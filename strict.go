@@ -0,0 +1,24 @@
+package errors
+
+import (
+	"sync/atomic"
+)
+
+var strictNilWrap int32
+
+// SetStrictNilWrap controls how WithMessage treats a nil error. Wrap returns
+// nil for a nil error, while WithMessage by default returns an error that
+// consists of the message alone, which can hide a missing error. In strict
+// mode WithMessage returns nil for a nil error as well, making both
+// functions behave the same.
+func SetStrictNilWrap(enabled bool) {
+	var mode int32
+	if enabled {
+		mode = 1
+	}
+	atomic.StoreInt32(&strictNilWrap, mode)
+}
+
+func strictNilWrapEnabled() bool {
+	return atomic.LoadInt32(&strictNilWrap) == 1
+}
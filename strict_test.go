@@ -0,0 +1,42 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func Test_strict_nil_wrap_disabled(t *testing.T) {
+	err := WithMessage(nil, "no error")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, "no error", err.Error())
+	assert.Nil(t, Wrap(nil, "no error"))
+}
+
+func Test_strict_nil_wrap_enabled(t *testing.T) {
+	SetStrictNilWrap(true)
+	defer SetStrictNilWrap(false)
+
+	assert.Nil(t, WithMessage(nil, "no error"))
+	assert.Nil(t, Wrap(nil, "no error"))
+}
+
+func Test_strict_nil_wrap_as_error_interface(t *testing.T) {
+	SetStrictNilWrap(true)
+	defer SetStrictNilWrap(false)
+
+	load := func(err error) error {
+		return WithMessage(err, "load user")
+	}
+
+	assert.True(t, load(nil) == nil)
+	assert.Equal(t, "load user: EOF", load(io.EOF).Error())
+}
+
+func Test_strict_nil_wrap_keeps_errors(t *testing.T) {
+	SetStrictNilWrap(true)
+	defer SetStrictNilWrap(false)
+
+	assert.Equal(t, "read: EOF", WithMessage(io.EOF, "read").Error())
+}
@@ -0,0 +1,54 @@
+package errors
+
+import (
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+var placeholderPattern = regexp.MustCompile(`\{([A-Za-z0-9_.\-]+)\}`)
+
+var (
+	markerMu           sync.RWMutex
+	missingFieldMarker string
+)
+
+// NewTemplate returns an error with a message containing named placeholders
+// such as "user {userID} not found". The message is not formatted; use
+// WithField to supply the values and Render to substitute them.
+// NewTemplate also records the stack trace at the point it was called.
+func NewTemplate(template string) *fundamental {
+	return newFundamental(callers(), template)
+}
+
+// SetMissingFieldMarker sets the text Render uses for placeholders without a
+// field. With an empty marker (the default) the placeholder is kept as is.
+func SetMissingFieldMarker(marker string) {
+	markerMu.Lock()
+	defer markerMu.Unlock()
+	missingFieldMarker = marker
+}
+
+// Render returns the message of err with every {name} placeholder replaced
+// by the value of the field with that name, as found by FindFields.
+func Render(err error) string {
+	if err == nil {
+		return ""
+	}
+	fields := FindFields(err)
+
+	markerMu.RLock()
+	marker := missingFieldMarker
+	markerMu.RUnlock()
+
+	return placeholderPattern.ReplaceAllStringFunc(err.Error(), func(placeholder string) string {
+		key := placeholder[1 : len(placeholder)-1]
+		if value, ok := fields[key]; ok {
+			return fmt.Sprint(value)
+		}
+		if marker != "" {
+			return marker
+		}
+		return placeholder
+	})
+}
@@ -0,0 +1,41 @@
+package errors
+
+import (
+	"github.com/confetti-framework/syslog"
+	"text/template"
+)
+
+// FuncMap returns functions that expose the metadata of an error to
+// templates, such as {{ errStatus . }}:
+//
+//	errMessage  the message of the error
+//	errStatus   the HTTP status, as returned by FindStatus
+//	errLevel    the syslog keyword of the level, e.g. "err", or ""
+//	errCode     the code set with WithCode, or ""
+//
+// The map can be passed to the Funcs method of both text/template and
+// html/template.
+func FuncMap() template.FuncMap {
+	return template.FuncMap{
+		"errMessage": func(err error) string {
+			if err == nil {
+				return ""
+			}
+			return err.Error()
+		},
+		"errStatus": func(err error) int {
+			status, _ := FindStatus(err)
+			return status
+		},
+		"errLevel": func(err error) string {
+			if level, ok := FindLevel(err); ok {
+				return syslog.KeyBySeverity(level)
+			}
+			return ""
+		},
+		"errCode": func(err error) string {
+			code, _ := FindCode(err)
+			return code
+		},
+	}
+}
@@ -0,0 +1,42 @@
+package errors
+
+import (
+	"bytes"
+	"github.com/confetti-framework/syslog/log_level"
+	"github.com/stretchr/testify/assert"
+	htmltemplate "html/template"
+	"io"
+	"testing"
+	"text/template"
+)
+
+func Test_func_map_text_template(t *testing.T) {
+	tmpl := template.Must(template.New("error").Funcs(FuncMap()).Parse(
+		`{{ errStatus . }} {{ errLevel . }} {{ errCode . }}: {{ errMessage . }}`,
+	))
+	err := WithCode(NotFound("user %d not found", 12).Level(log_level.WARNING), "E1")
+
+	var buf bytes.Buffer
+	assert.Nil(t, tmpl.Execute(&buf, err))
+	assert.Equal(t, "404 warning E1: user 12 not found", buf.String())
+}
+
+func Test_func_map_without_metadata(t *testing.T) {
+	tmpl := template.Must(template.New("error").Funcs(FuncMap()).Parse(
+		`[{{ errStatus . }}][{{ errLevel . }}][{{ errCode . }}][{{ errMessage . }}]`,
+	))
+
+	var buf bytes.Buffer
+	assert.Nil(t, tmpl.Execute(&buf, io.EOF))
+	assert.Equal(t, "[500][][][EOF]", buf.String())
+}
+
+func Test_func_map_html_template(t *testing.T) {
+	tmpl := htmltemplate.Must(htmltemplate.New("error").Funcs(FuncMap()).Parse(
+		`<p class="status-{{ errStatus . }}">{{ errMessage . }}</p>`,
+	))
+
+	var buf bytes.Buffer
+	assert.Nil(t, tmpl.Execute(&buf, BadRequest("<script> is not a name")))
+	assert.Equal(t, `<p class="status-400">&lt;script&gt; is not a name</p>`, buf.String())
+}
@@ -0,0 +1,39 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func Test_render_nil(t *testing.T) {
+	assert.Equal(t, "", Render(nil))
+}
+
+func Test_render_substitutes_fields(t *testing.T) {
+	var err error = NewTemplate("user {userID} not found in {account}")
+	err = WithField(err, "userID", 12)
+	err = WithField(err, "account", "acme")
+
+	assert.Equal(t, "user 12 not found in acme", Render(err))
+	assert.Equal(t, "user {userID} not found in {account}", err.Error())
+}
+
+func Test_render_substitutes_wrapped_messages(t *testing.T) {
+	err := WithField(Wrap(NewTemplate("user {userID} not found"), "request {requestID} failed"), "userID", 12)
+
+	assert.Equal(t, "request {requestID} failed: user 12 not found", Render(err))
+}
+
+func Test_render_missing_field_marker(t *testing.T) {
+	err := WithField(NewTemplate("user {userID} not found in {account}"), "userID", 12)
+
+	assert.Equal(t, "user 12 not found in {account}", Render(err))
+
+	SetMissingFieldMarker("<missing>")
+	defer SetMissingFieldMarker("")
+	assert.Equal(t, "user 12 not found in <missing>", Render(err))
+}
+
+func Test_new_template_does_not_format(t *testing.T) {
+	assert.Equal(t, "100% of {quota} used", NewTemplate("100% of {quota} used").Error())
+}
@@ -0,0 +1,83 @@
+package errors
+
+import (
+	"fmt"
+	syslog "github.com/confetti-framework/syslog/log_level"
+	"time"
+)
+
+// WithTime annotates err with the current time, as read from the clock set
+// with SetClock, to record when the error occurred. If err is nil, WithTime
+// returns nil.
+func WithTime(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &withTime{
+		err,
+		currentTime(),
+	}
+}
+
+// FindTime returns the time recorded by the innermost WithTime in the chain
+// of err, which is closest to the moment the error was created.
+func FindTime(err error) (time.Time, bool) {
+	links := Links(err)
+	for i := len(links) - 1; i >= 0; i-- {
+		if timeHolder, ok := links[i].(*withTime); ok {
+			return timeHolder.time, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// Age returns how long ago the time found by FindTime is, according to the
+// clock set with SetClock.
+func Age(err error) (time.Duration, bool) {
+	created, ok := FindTime(err)
+	if !ok {
+		return 0, false
+	}
+	return currentTime().Sub(created), true
+}
+
+type withTime struct {
+	cause error
+	time  time.Time
+}
+
+func (w *withTime) Error() string {
+	return w.cause.Error()
+}
+
+func (w *withTime) Format(st fmt.State, verb rune) {
+	formatCause(st, verb, w, w.cause)
+}
+
+func (w *withTime) Unwrap() error {
+	return w.cause
+}
+
+func (w *withTime) Cause() error {
+	return Unwrap(w)
+}
+
+func (w *withTime) Wrap(message string, args ...interface{}) *withMessage {
+	return newMessage(w, message, args...)
+}
+
+func (w *withTime) Level(level syslog.Level) *withLevel {
+	return withLevelAt(w, level, 1)
+}
+
+func (w *withTime) Status(status int) *withStatus {
+	return WithStatus(w, status)
+}
+
+func (w *withTime) Alert() *withAlert {
+	return &withAlert{w}
+}
+
+func (w *withTime) With(key string, value interface{}) *withField {
+	return &withField{cause: w, key: key, value: value}
+}
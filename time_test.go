@@ -0,0 +1,47 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+	"time"
+)
+
+func Test_with_time_nil(t *testing.T) {
+	assert.Nil(t, WithTime(nil))
+}
+
+func Test_find_time_innermost(t *testing.T) {
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	SetClock(func() time.Time { return now })
+	defer SetClock(nil)
+
+	err := WithTime(io.EOF)
+	now = now.Add(time.Minute)
+	err = WithTime(Wrap(err, "read"))
+
+	created, ok := FindTime(err)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC), created)
+	assert.Equal(t, "read: EOF", err.Error())
+}
+
+func Test_age(t *testing.T) {
+	now := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	SetClock(func() time.Time { return now })
+	defer SetClock(nil)
+	err := Wrap(WithTime(io.EOF), "read")
+
+	now = now.Add(90 * time.Second)
+
+	age, ok := Age(err)
+	assert.True(t, ok)
+	assert.Equal(t, 90*time.Second, age)
+}
+
+func Test_age_without_time(t *testing.T) {
+	_, ok := Age(Wrap(io.EOF, "read"))
+	assert.False(t, ok)
+	_, ok = FindTime(nil)
+	assert.False(t, ok)
+}
@@ -0,0 +1,22 @@
+package errors
+
+import (
+	"time"
+)
+
+const elapsedField = "elapsed"
+
+// WrapTimed returns an error annotating err with a stack trace, the
+// supplied message and the time elapsed since start, stored as a
+// time.Duration in the field "elapsed". If err is nil, WrapTimed returns nil.
+func WrapTimed(err error, start time.Time, message string, args ...interface{}) error {
+	if err == nil {
+		return nil
+	}
+	elapsed := currentTime().Sub(start)
+	return &withField{
+		cause: newWrap(err, callers(), message, args...),
+		key:   elapsedField,
+		value: elapsed,
+	}
+}
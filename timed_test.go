@@ -0,0 +1,36 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+	"time"
+)
+
+func Test_wrap_timed_nil(t *testing.T) {
+	assert.Nil(t, WrapTimed(nil, time.Now(), "load user"))
+}
+
+func Test_wrap_timed_elapsed(t *testing.T) {
+	start := time.Date(2020, 1, 1, 12, 0, 0, 0, time.UTC)
+	SetClock(func() time.Time { return start.Add(1500 * time.Millisecond) })
+	defer SetClock(nil)
+
+	err := WrapTimed(io.EOF, start, "load user %d", 12)
+
+	assert.Equal(t, "load user 12: EOF", err.Error())
+	assert.Equal(t, 1500*time.Millisecond, FindFields(err)["elapsed"])
+	assert.True(t, Is(err, io.EOF))
+	_, ok := FindStack(err)
+	assert.True(t, ok)
+}
+
+func Test_wrap_timed_real_clock(t *testing.T) {
+	start := time.Now().Add(-time.Second)
+
+	elapsed, ok := FindFields(WrapTimed(io.EOF, start, "load user"))["elapsed"].(time.Duration)
+
+	assert.True(t, ok)
+	assert.True(t, elapsed >= time.Second)
+	assert.True(t, elapsed < time.Minute)
+}
@@ -0,0 +1,103 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"sync/atomic"
+)
+
+var traceFormat int32
+
+// SetTraceFormat controls whether %+v prints the breadcrumb trail of
+// FindTrace, one "at file:line: message" line per entry, instead of the
+// full stack traces. The message of a root cause without a stack trace,
+// such as io.EOF, is printed as the last line, if there is one. It is off
+// by default, so existing log formats stay stable.
+func SetTraceFormat(enabled bool) {
+	var format int32
+	if enabled {
+		format = 1
+	}
+	atomic.StoreInt32(&traceFormat, format)
+}
+
+func traceFormatEnabled() bool {
+	return atomic.LoadInt32(&traceFormat) == 1
+}
+
+// TraceEntry pairs a message with the frame where it was added to an error.
+type TraceEntry struct {
+	Frame   FrameInfo
+	Message string
+}
+
+// String formats the entry as "at file:line: message".
+func (t TraceEntry) String() string {
+	return fmt.Sprintf("at %s:%d: %s", t.Frame.File, t.Frame.Line, t.Message)
+}
+
+// FindTrace returns a breadcrumb trail of err from the outermost to the
+// innermost layer: the message of every Wrap, WrapLazy and Because paired
+// with the frame where it was called, followed by the message of New paired
+// with the frame where New was called. Layers without a message or without
+// a stack trace are left out.
+func FindTrace(err error) []TraceEntry {
+	var trace []TraceEntry
+	var pending *withStack
+	for _, link := range Links(err) {
+		switch layer := link.(type) {
+		case *withStack:
+			pending = layer
+		case *withMessage:
+			if pending != nil {
+				trace = appendTraceEntry(trace, pending.StackTrace(), layer.msg)
+				pending = nil
+			}
+		case *withLazyMessage:
+			if pending != nil {
+				trace = appendTraceEntry(trace, pending.StackTrace(), layer.message())
+				pending = nil
+			}
+		case *withBecause:
+			if layer.cause == nil {
+				continue
+			}
+			trace = appendTraceEntry(trace, layer.StackTrace(), layer.outer.msg)
+			pending = nil
+		case *fundamental:
+			trace = appendTraceEntry(trace, layer.StackTrace(), layer.msg)
+			pending = nil
+		}
+	}
+	return trace
+}
+
+func appendTraceEntry(trace []TraceEntry, stack StackTrace, message string) []TraceEntry {
+	if len(stack) == 0 {
+		return trace
+	}
+	return append(trace, TraceEntry{stack[0].Info(), message})
+}
+
+// formatTrace writes the trail of err for %+v if SetTraceFormat is enabled,
+// and reports whether it did.
+func formatTrace(s fmt.State, err error) bool {
+	if !traceFormatEnabled() {
+		return false
+	}
+	trace := FindTrace(err)
+	for i, entry := range trace {
+		if i > 0 {
+			io.WriteString(s, "\n")
+		}
+		io.WriteString(s, entry.String())
+	}
+	root := Unwrap(err)
+	if _, ok := root.(*fundamental); !ok && root != nil {
+		if len(trace) > 0 {
+			io.WriteString(s, "\n")
+		}
+		io.WriteString(s, root.Error())
+	}
+	return true
+}
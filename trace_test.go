@@ -0,0 +1,107 @@
+package errors
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"strings"
+	"testing"
+)
+
+func loadRow() error {
+	return New("row not found")
+}
+
+func loadUser() error {
+	return Wrap(loadRow(), "load user %d", 12)
+}
+
+func handleRequest() error {
+	return Wrap(loadUser(), "handle request")
+}
+
+func Test_trace_nil(t *testing.T) {
+	assert.Nil(t, FindTrace(nil))
+}
+
+func Test_trace_pairs_messages_with_capture_sites(t *testing.T) {
+	trace := FindTrace(handleRequest())
+
+	assert.Len(t, trace, 3)
+	assert.Equal(t, "handle request", trace[0].Message)
+	assert.Equal(t, "handleRequest", funcname(trace[0].Frame.Function))
+	assert.Equal(t, "load user 12", trace[1].Message)
+	assert.Equal(t, "loadUser", funcname(trace[1].Frame.Function))
+	assert.Equal(t, "row not found", trace[2].Message)
+	assert.Equal(t, "loadRow", funcname(trace[2].Frame.Function))
+}
+
+func Test_trace_skips_layers_without_stack_or_message(t *testing.T) {
+	err := WithMessage(WithStack(Wrap(io.EOF, "read failed")), "no stack")
+
+	trace := FindTrace(err)
+	assert.Len(t, trace, 1)
+	assert.Equal(t, "read failed", trace[0].Message)
+}
+
+func Test_trace_entry_string(t *testing.T) {
+	entry := TraceEntry{FrameInfo{"main.main", "/src/main.go", 7}, "handle request"}
+
+	assert.Equal(t, "at /src/main.go:7: handle request", entry.String())
+}
+
+func Test_trace_includes_lazy_messages(t *testing.T) {
+	err := Wrap(WrapLazy(loadRow(), func() string { return "load user 12" }), "handle request")
+
+	trace := FindTrace(err)
+	assert.Len(t, trace, 3)
+	assert.Equal(t, "handle request", trace[0].Message)
+	assert.Equal(t, "load user 12", trace[1].Message)
+	assert.Equal(t, "Test_trace_includes_lazy_messages", funcname(trace[1].Frame.Function))
+	assert.Equal(t, "row not found", trace[2].Message)
+}
+
+func Test_trace_includes_because(t *testing.T) {
+	err := New("load user").Because(io.EOF)
+
+	trace := FindTrace(err)
+	assert.Len(t, trace, 1)
+	assert.Equal(t, "load user", trace[0].Message)
+}
+
+func Test_trace_format(t *testing.T) {
+	SetTraceFormat(true)
+	defer SetTraceFormat(false)
+
+	lines := strings.Split(fmt.Sprintf("%+v", WithStatus(handleRequest(), 404)), "\n")
+
+	assert.Len(t, lines, 3)
+	assert.Regexp(t, `^at .+/errors/trace_test.go:\d+: handle request$`, lines[0])
+	assert.Regexp(t, `^at .+/errors/trace_test.go:\d+: load user 12$`, lines[1])
+	assert.Regexp(t, `^at .+/errors/trace_test.go:\d+: row not found$`, lines[2])
+}
+
+func Test_trace_format_foreign_root(t *testing.T) {
+	SetTraceFormat(true)
+	defer SetTraceFormat(false)
+
+	lines := strings.Split(fmt.Sprintf("%+v", Wrap(io.EOF, "read failed")), "\n")
+
+	assert.Len(t, lines, 2)
+	assert.Regexp(t, `^at .+/errors/trace_test.go:\d+: read failed$`, lines[0])
+	assert.Equal(t, "EOF", lines[1])
+}
+
+func Test_trace_format_nil_root(t *testing.T) {
+	SetTraceFormat(true)
+	defer SetTraceFormat(false)
+
+	formatted := fmt.Sprintf("%+v", WithStack(WithMessage(nil, "read failed")))
+
+	assert.NotContains(t, formatted, "PANIC")
+	assert.Regexp(t, `^at .+/errors/trace_test.go:\d+: read failed$`, formatted)
+}
+
+func Test_trace_format_disabled(t *testing.T) {
+	assert.Contains(t, fmt.Sprintf("%+v", handleRequest()), "\n\t")
+}
@@ -0,0 +1,47 @@
+package errors
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// packagePrefix starts the names of the functions of this package.
+const packagePrefix = "github.com/confetti-framework/errors."
+
+var trimStack int32
+
+// SetTrimStack controls whether stack traces leave out the frames that are
+// noise to the reader: frames of this package above the code that created
+// the error, and the frames of the runtime that started the goroutine. It
+// applies to %+v and StackTrace of errors with a captured stack, also when
+// they were created before. It is off by default.
+func SetTrimStack(enabled bool) {
+	var mode int32
+	if enabled {
+		mode = 1
+	}
+	atomic.StoreInt32(&trimStack, mode)
+}
+
+func trimStackEnabled() bool {
+	return atomic.LoadInt32(&trimStack) == 1
+}
+
+// trimFrames returns pcs without the leading frames of this package and the
+// trailing frames of the runtime.
+func trimFrames(pcs []uintptr) []uintptr {
+	start, end := 0, len(pcs)
+	for start < end && isPackageFrame(Frame(pcs[start])) {
+		start++
+	}
+	for end > start && strings.HasPrefix(Frame(pcs[end-1]).name(), "runtime.") {
+		end--
+	}
+	return pcs[start:end]
+}
+
+// isPackageFrame reports whether f is in this package, apart from its
+// tests.
+func isPackageFrame(f Frame) bool {
+	return strings.HasPrefix(f.name(), packagePrefix) && !strings.HasSuffix(f.file(), "_test.go")
+}
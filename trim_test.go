@@ -0,0 +1,44 @@
+package errors
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func Test_trim_stack_disabled(t *testing.T) {
+	trace := fmt.Sprintf("%+v", New("untrimmed"))
+
+	assert.Contains(t, trace, "runtime.goexit")
+}
+
+func Test_trim_stack_drops_runtime_frames(t *testing.T) {
+	SetTrimStack(true)
+	defer SetTrimStack(false)
+
+	err := New("trimmed")
+	trace := fmt.Sprintf("%+v", err)
+
+	assert.NotContains(t, trace, "runtime.goexit")
+	assert.True(t, strings.HasPrefix(trace, "trimmed\n"+packagePrefix+"Test_trim_stack_drops_runtime_frames\n"))
+	for _, frame := range err.StackTrace() {
+		assert.False(t, strings.HasPrefix(frame.name(), "runtime."), frame.name())
+	}
+}
+
+func Test_trim_stack_drops_package_frames(t *testing.T) {
+	// Capture(-1) starts at the frame of Capture itself
+	var st stack = defaultStackCapturer{}.Capture(-1)
+	assert.Equal(t, "defaultStackCapturer.Capture", funcname(Frame(st[0]).name()))
+
+	SetTrimStack(true)
+	defer SetTrimStack(false)
+
+	frames := st.StackTrace()
+	assert.Equal(t, "Test_trim_stack_drops_package_frames", funcname(frames[0].name()))
+	for _, frame := range frames {
+		assert.False(t, isPackageFrame(frame), frame.name())
+		assert.NotEqual(t, "runtime.goexit", frame.name())
+	}
+}
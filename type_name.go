@@ -0,0 +1,16 @@
+package errors
+
+import (
+	"fmt"
+)
+
+// RootTypeName returns the name of the concrete type of the root cause of
+// err, such as "*net.OpError" or "*errors.fundamental". Because it does not
+// depend on messages it is a low cardinality signal to classify failures.
+// RootTypeName returns "" if err is nil.
+func RootTypeName(err error) string {
+	if err == nil {
+		return ""
+	}
+	return fmt.Sprintf("%T", Unwrap(err))
+}
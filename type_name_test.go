@@ -0,0 +1,29 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+type typeNameError struct{}
+
+func (typeNameError) Error() string { return "type name" }
+
+func Test_root_type_name_nil(t *testing.T) {
+	assert.Equal(t, "", RootTypeName(nil))
+}
+
+func Test_root_type_name_wrapped_eof(t *testing.T) {
+	assert.Equal(t, "*errors.errorString", RootTypeName(Wrap(io.EOF, "read")))
+}
+
+func Test_root_type_name_wrapped_custom_type(t *testing.T) {
+	err := WithStatus(Wrap(typeNameError{}, "read"), 500)
+
+	assert.Equal(t, "errors.typeNameError", RootTypeName(err))
+}
+
+func Test_root_type_name_fundamental(t *testing.T) {
+	assert.Equal(t, "*errors.fundamental", RootTypeName(New("x").Wrap("y")))
+}
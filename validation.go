@@ -0,0 +1,85 @@
+package errors
+
+import (
+	"fmt"
+	syslog "github.com/confetti-framework/syslog/log_level"
+	net "net/http"
+)
+
+// NewValidation returns an error with status 422 for failed validation of
+// the given fields, mapping each field name to its message. The message of
+// the error summarizes the number of invalid fields; ValidationErrors
+// returns the messages per field. The messages are fields of the error as
+// well, keyed by field name, so FindFields and the log formats include them.
+// NewValidation also records the stack trace at the point it was called.
+func NewValidation(fieldErrors map[string]string) error {
+	message := "validation failed for 1 field"
+	if len(fieldErrors) != 1 {
+		message = fmt.Sprintf("validation failed for %d fields", len(fieldErrors))
+	}
+	fields := make(map[string]string, len(fieldErrors))
+	for field, fieldMessage := range fieldErrors {
+		fields[field] = fieldMessage
+	}
+	return WithStatus(&withValidation{
+		cause:  newFundamental(callers(), message),
+		fields: fields,
+	}, net.StatusUnprocessableEntity)
+}
+
+// ValidationErrors returns a copy of the messages per field of the outermost
+// error created by NewValidation in the chain of err.
+func ValidationErrors(err error) (map[string]string, bool) {
+	var validationHolder *withValidation
+
+	if !As(err, &validationHolder) {
+		return nil, false
+	}
+
+	fields := make(map[string]string, len(validationHolder.fields))
+	for field, message := range validationHolder.fields {
+		fields[field] = message
+	}
+	return fields, true
+}
+
+type withValidation struct {
+	cause  error
+	fields map[string]string
+}
+
+func (w *withValidation) Error() string {
+	return w.cause.Error()
+}
+
+func (w *withValidation) Format(st fmt.State, verb rune) {
+	formatCause(st, verb, w, w.cause)
+}
+
+func (w *withValidation) Unwrap() error {
+	return w.cause
+}
+
+func (w *withValidation) Cause() error {
+	return Unwrap(w)
+}
+
+func (w *withValidation) Wrap(message string, args ...interface{}) *withMessage {
+	return newMessage(w, message, args...)
+}
+
+func (w *withValidation) Level(level syslog.Level) *withLevel {
+	return withLevelAt(w, level, 1)
+}
+
+func (w *withValidation) Status(status int) *withStatus {
+	return WithStatus(w, status)
+}
+
+func (w *withValidation) Alert() *withAlert {
+	return &withAlert{w}
+}
+
+func (w *withValidation) With(key string, value interface{}) *withField {
+	return &withField{cause: w, key: key, value: value}
+}
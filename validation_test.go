@@ -0,0 +1,62 @@
+package errors
+
+import (
+	"github.com/stretchr/testify/assert"
+	net "net/http"
+	"testing"
+)
+
+func Test_validation_status(t *testing.T) {
+	err := NewValidation(map[string]string{"email": "is required"})
+
+	status, ok := FindStatus(err)
+	assert.True(t, ok)
+	assert.Equal(t, net.StatusUnprocessableEntity, status)
+}
+
+func Test_validation_message(t *testing.T) {
+	assert.Equal(t, "validation failed for 1 field", NewValidation(map[string]string{"email": "is required"}).Error())
+	assert.Equal(t, "validation failed for 2 fields", NewValidation(map[string]string{
+		"email": "is required",
+		"name":  "is too long",
+	}).Error())
+	assert.Equal(t, "validation failed for 0 fields", NewValidation(nil).Error())
+}
+
+func Test_validation_errors(t *testing.T) {
+	input := map[string]string{"email": "is required", "name": "is too long"}
+	err := Wrap(NewValidation(input), "cannot register user")
+	input["email"] = "changed"
+
+	fields, ok := ValidationErrors(err)
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"email": "is required", "name": "is too long"}, fields)
+
+	fields["name"] = "changed"
+	fields, _ = ValidationErrors(err)
+	assert.Equal(t, "is too long", fields["name"])
+}
+
+func Test_validation_errors_as_fields(t *testing.T) {
+	err := Wrap(NewValidation(map[string]string{"email": "is required"}), "cannot register user")
+
+	assert.Equal(t, map[string]interface{}{"email": "is required"}, FindFields(err))
+	assert.True(t, HasField(err, "email"))
+	email, _ := FieldString(err, "email")
+	assert.Equal(t, "is required", email)
+	assert.Equal(t, "override", FindFields(WithField(err, "email", "override"))["email"])
+	assert.Contains(t, Logfmt(err), `email="is required"`)
+}
+
+func Test_validation_errors_without_validation(t *testing.T) {
+	fields, ok := ValidationErrors(New("not found"))
+	assert.False(t, ok)
+	assert.Nil(t, fields)
+}
+
+func Test_validation_stack(t *testing.T) {
+	stack, ok := FindStack(NewValidation(nil))
+
+	assert.True(t, ok)
+	assert.Equal(t, "Test_validation_stack", funcname(stack[0].name()))
+}